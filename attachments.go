@@ -0,0 +1,14 @@
+package redmine
+
+// Attachment is a file attached to an issue, wiki page, news item or other container.
+type Attachment struct {
+	Id           int       `json:"id"`
+	Filename     string    `json:"filename"`
+	Filesize     int       `json:"filesize"`
+	ContentType  string    `json:"content_type"`
+	Description  string    `json:"description"`
+	ContentURL   string    `json:"content_url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	Author       *IdName   `json:"author"`
+	CreatedOn    Timestamp `json:"created_on"`
+}
@@ -0,0 +1,157 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBulkConcurrency is the number of concurrent requests used by bulk operations
+// when BulkOptions.Concurrency is not set.
+var DefaultBulkConcurrency = 4
+
+// BulkOptions configures BulkUpdateIssues and BulkCreateIssues.
+type BulkOptions struct {
+	Concurrency int // maximum number of requests in flight, DefaultBulkConcurrency when zero or less
+	// DryRun checks every item without changing anything: updated issues are
+	// fetched to make sure they exist, and issues to create are validated.
+	DryRun bool
+	// StopOnError stops starting new requests after the first failure. Requests
+	// already in flight are completed and the remaining items are reported as skipped.
+	StopOnError bool
+	UserName    string // optional, sent as X-Redmine-Switch-User
+}
+
+// BulkResult reports the outcome of one item of a bulk operation.
+type BulkResult struct {
+	Index   int    // position of the item in the input
+	Id      int    // id of the updated or created issue, 0 when no issue was created
+	Issue   *Issue // the created issue, nil for updates and dry runs
+	Err     error
+	Skipped bool // the item was not attempted because of StopOnError or context cancellation
+}
+
+// BulkError is returned by bulk operations when some items failed or were skipped.
+// Per-item errors are in the results.
+type BulkError struct {
+	Total   int
+	Failed  int
+	Skipped int
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("bulk operation: %d of %d items failed, %d skipped", e.Failed, e.Total, e.Skipped)
+}
+
+// BulkUpdateIssues applies the same patch to every issue. Redmine has no bulk update
+// endpoint, so one request is sent per issue. The results are in the order of ids;
+// a *BulkError is returned when any item failed or was skipped.
+func (c *Client) BulkUpdateIssues(ctx context.Context, ids []int, p *IssuePatch, opts BulkOptions) ([]BulkResult, error) {
+	if p == nil || p.IsEmpty() {
+		return nil, errors.New("patch is empty")
+	}
+	body := struct {
+		Issue *IssuePatch `json:"issue"`
+	}{p}
+	results, err := runBulk(ctx, len(ids), opts, func(ctx context.Context, i int) BulkResult {
+		r := BulkResult{Index: i}
+		if opts.DryRun {
+			_, r.Err = getOneIssueContext(ctx, c, ids[i], nil)
+		} else {
+			r.Err = c.putIssueContext(ctx, ids[i], body, switchUser(opts)...)
+		}
+		return r
+	})
+	for i := range results {
+		results[i].Id = ids[i]
+	}
+	return results, err
+}
+
+// BulkCreateIssues creates every issue, one request per issue. The results are in
+// the order of issues; a *BulkError is returned when any item failed or was skipped.
+func (c *Client) BulkCreateIssues(ctx context.Context, issues []IssueToCreate, opts BulkOptions) ([]BulkResult, error) {
+	return runBulk(ctx, len(issues), opts, func(ctx context.Context, i int) BulkResult {
+		r := BulkResult{Index: i}
+		if r.Err = validateIssueToCreate(&issues[i]); r.Err != nil || opts.DryRun {
+			return r
+		}
+		r.Issue, r.Err = c.createIssue(ctx, issues[i], switchUser(opts)...)
+		if r.Issue != nil {
+			r.Id = r.Issue.Id
+		}
+		return r
+	})
+}
+
+func validateIssueToCreate(issue *IssueToCreate) error {
+	if issue.ProjectId == 0 {
+		return errors.New("project_id is required")
+	}
+	if issue.Subject == "" {
+		return errors.New("subject is required")
+	}
+	return nil
+}
+
+func switchUser(opts BulkOptions) []string {
+	if opts.UserName == "" {
+		return nil
+	}
+	return []string{opts.UserName}
+}
+
+// runBulk calls do for every index with at most opts.Concurrency calls running at once.
+func runBulk(ctx context.Context, n int, opts BulkOptions, do func(ctx context.Context, i int) BulkResult) ([]BulkResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	results := make([]BulkResult, n)
+	jobs := make(chan int)
+	var stopped bool
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				mu.Lock()
+				skip := stopped
+				mu.Unlock()
+				if skip || ctx.Err() != nil {
+					results[i] = BulkResult{Index: i, Err: ctx.Err(), Skipped: true}
+					continue
+				}
+				r := do(ctx, i)
+				if r.Err != nil && opts.StopOnError {
+					mu.Lock()
+					stopped = true
+					mu.Unlock()
+				}
+				results[i] = r
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	bulkErr := &BulkError{Total: n}
+	for _, r := range results {
+		if r.Skipped {
+			bulkErr.Skipped++
+		} else if r.Err != nil {
+			bulkErr.Failed++
+		}
+	}
+	if bulkErr.Failed > 0 || bulkErr.Skipped > 0 {
+		return results, bulkErr
+	}
+	return results, nil
+}
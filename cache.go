@@ -0,0 +1,352 @@
+package redmine
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a GET response stored by the client cache.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	ETag       string
+	StoredAt   time.Time
+	Expires    time.Time // the response is served without contacting Redmine until then
+	Tags       []string  // resources named in the request path, see CacheOptions
+}
+
+// Cache stores responses for the client cache. Implementations must be safe for
+// concurrent use. A backend shared between processes must apply DeleteByTag and
+// Clear to the entries written by every process, so that a write made through
+// one client invalidates what the others cached.
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+	// DeleteByTag removes every response whose Tags contain tag.
+	DeleteByTag(tag string)
+	// Clear removes every response.
+	Clear()
+}
+
+// DefaultMemoryCacheSize is the number of responses kept by NewMemoryCache.
+var DefaultMemoryCacheSize = 1000
+
+// MemoryCache is an in-process Cache. When full, the least recently used response
+// is evicted. Expired responses without an ETag cannot be revalidated and are
+// dropped when looked up.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // of *memoryCacheEntry, most recently used first
+	entries    map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewMemoryCache returns a MemoryCache holding at most DefaultMemoryCacheSize responses.
+func NewMemoryCache() *MemoryCache {
+	return NewMemoryCacheSize(DefaultMemoryCacheSize)
+}
+
+// NewMemoryCacheSize returns a MemoryCache holding at most maxEntries responses.
+// A maxEntries of zero or less means no limit.
+func NewMemoryCacheSize(maxEntries int) *MemoryCache {
+	return &MemoryCache{maxEntries: maxEntries, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	resp := elem.Value.(*memoryCacheEntry).resp
+	if resp.ETag == "" && !time.Now().Before(resp.Expires) {
+		m.remove(elem)
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return resp, true
+}
+
+func (m *MemoryCache) Set(key string, resp *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryCacheEntry).resp = resp
+		m.order.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, resp: resp})
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *MemoryCache) DeleteByTag(tag string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, elem := range m.entries {
+		for _, t := range elem.Value.(*memoryCacheEntry).resp.Tags {
+			if t == tag {
+				m.remove(elem)
+				break
+			}
+		}
+	}
+}
+
+func (m *MemoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.order.Init()
+	m.entries = make(map[string]*list.Element)
+}
+
+// Len returns the number of cached responses.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+func (m *MemoryCache) remove(elem *list.Element) {
+	m.order.Remove(elem)
+	delete(m.entries, elem.Value.(*memoryCacheEntry).key)
+}
+
+// CacheOptions configures the client cache.
+//
+// Resources are named after the collection in the request path, e.g. "trackers",
+// "issue_statuses" or "versions" for /projects/1/versions.json. Enumerations are
+// named after their type, e.g. "issue_priorities" for /enumerations/issue_priorities.json.
+// A resource is kept fresh for TTLs[resource], or DefaultTTL when it has no entry.
+// A TTL of zero defers to the Cache-Control max-age sent by Redmine, and a negative
+// TTL disables caching for the resource. Stale responses carrying an ETag are
+// revalidated with If-None-Match rather than downloaded again.
+type CacheOptions struct {
+	Backend    Cache // defaults to a new MemoryCache
+	DefaultTTL time.Duration
+	TTLs       map[string]time.Duration
+}
+
+// EnableCache makes the client cache GET responses. Any other request invalidates
+// the cached responses of the resources named in its path, so that e.g. updating
+// a version drops the cached version lists.
+func (c *Client) EnableCache(opts CacheOptions) {
+	if opts.Backend == nil {
+		opts.Backend = NewMemoryCache()
+	}
+	base := c.Client
+	if base == nil {
+		base = http.DefaultClient
+	}
+	next := base.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if t, ok := next.(*cacheTransport); ok {
+		next = t.next
+	}
+	prefix := ""
+	if u, err := url.Parse(c.endpoint); err == nil {
+		prefix = strings.TrimSuffix(u.Path, "/")
+	}
+	hc := *base
+	hc.Transport = &cacheTransport{
+		next:   next,
+		opts:   opts,
+		prefix: prefix,
+	}
+	c.Client = &hc
+}
+
+// InvalidateCache drops the cached responses of the given resources, or every cached
+// response when none is given. It does nothing when the cache is not enabled.
+func (c *Client) InvalidateCache(resources ...string) {
+	if c.Client == nil {
+		return
+	}
+	t, ok := c.Client.Transport.(*cacheTransport)
+	if !ok {
+		return
+	}
+	if len(resources) == 0 {
+		t.opts.Backend.Clear()
+		return
+	}
+	t.invalidate(resources)
+}
+
+type cacheTransport struct {
+	next   http.RoundTripper
+	opts   CacheOptions
+	prefix string
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tags := t.resources(req.URL.Path)
+	if req.Method != "GET" {
+		res, err := t.next.RoundTrip(req)
+		if req.Method != "HEAD" {
+			t.invalidate(tags)
+		}
+		return res, err
+	}
+	ttl, cacheable := t.ttl(tags)
+	if !cacheable || req.Header.Get("If-None-Match") != "" || hasDirective(req.Header, "no-store") {
+		return t.next.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached, ok := t.opts.Backend.Get(key)
+	if ok && time.Now().Before(cached.Expires) && !hasDirective(req.Header, "no-cache") {
+		return cached.response(req), nil
+	}
+	if ok && cached.ETag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotModified && ok {
+		res.Body.Close()
+		refreshed := *cached
+		refreshed.StoredAt = time.Now()
+		refreshed.Expires = refreshed.StoredAt.Add(freshness(res.Header, ttl))
+		t.opts.Backend.Set(key, &refreshed)
+		return refreshed.response(req), nil
+	}
+	if res.StatusCode != http.StatusOK || hasDirective(res.Header, "no-store") {
+		return res, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	entry := &CachedResponse{
+		StatusCode: res.StatusCode,
+		Header:     res.Header.Clone(),
+		Body:       body,
+		ETag:       res.Header.Get("ETag"),
+		StoredAt:   time.Now(),
+		Tags:       tags,
+	}
+	entry.Expires = entry.StoredAt.Add(freshness(res.Header, ttl))
+	if entry.ETag != "" || entry.Expires.After(entry.StoredAt) {
+		t.opts.Backend.Set(key, entry)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// resources returns the collections named in the path, e.g. ["projects", "versions"]
+// for /projects/1/versions.json or ["enumerations", "issue_priorities"] for
+// /enumerations/issue_priorities.json.
+func (t *cacheTransport) resources(path string) []string {
+	path = strings.TrimPrefix(path, t.prefix)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range segments {
+		segments[i] = strings.TrimSuffix(segments[i], ".json")
+	}
+	if segments[0] == "enumerations" {
+		// the second segment is the enumeration type, not an id
+		return segments
+	}
+	var resources []string
+	for i := 0; i < len(segments); i += 2 {
+		resources = append(resources, segments[i])
+	}
+	return resources
+}
+
+// ttl returns the TTL configured for the innermost resource of the path.
+func (t *cacheTransport) ttl(resources []string) (time.Duration, bool) {
+	ttl := t.opts.DefaultTTL
+	if len(resources) > 0 {
+		if v, ok := t.opts.TTLs[resources[len(resources)-1]]; ok {
+			ttl = v
+		}
+	}
+	return ttl, ttl >= 0
+}
+
+func (t *cacheTransport) invalidate(tags []string) {
+	for _, tag := range tags {
+		t.opts.Backend.DeleteByTag(tag)
+	}
+}
+
+// cacheKey identifies a response by URL and by the credentials selecting the acting
+// user. The API key is taken out of the URL and only a hash of it is kept, so that
+// keys do not end up in the cache backend.
+func cacheKey(req *http.Request) string {
+	u := *req.URL
+	query := u.Query()
+	apikey := query.Get("key")
+	query.Del("key")
+	u.RawQuery = query.Encode()
+	sum := sha256.Sum256([]byte(apikey + "\x00" + req.Header.Get("X-Redmine-API-Key")))
+	return u.String() + "\x00" + hex.EncodeToString(sum[:]) + "\x00" + req.Header.Get("X-Redmine-Switch-User")
+}
+
+// freshness returns ttl, or the max-age of the response when ttl is zero.
+func freshness(h http.Header, ttl time.Duration) time.Duration {
+	if hasDirective(h, "no-cache") {
+		return 0
+	}
+	if ttl > 0 {
+		return ttl
+	}
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.HasPrefix(directive, "max-age=") {
+			if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && seconds > 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return 0
+}
+
+func hasDirective(h http.Header, name string) bool {
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -19,6 +19,9 @@ type Client struct {
 	Offset int
 }
 
+// ErrNotFound is returned when Redmine answers 404 Not Found.
+var ErrNotFound = errors.New("not found")
+
 var DefaultLimit int = -1  // "-1" means "No setting"
 var DefaultOffset int = -1 //"-1" means "No setting"
 
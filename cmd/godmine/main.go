@@ -19,6 +19,7 @@ import (
 	"strings"
 	"time"
 
+	lkredmine "github.com/LekoLabs/go-redmine"
 	"github.com/mattn/go-redmine"
 	"github.com/mattn/go-shellwords"
 )
@@ -299,25 +300,21 @@ func deleteIssue(id int) {
 }
 
 func closeIssue(id int) {
-	c := redmine.NewClient(conf.Endpoint, conf.Apikey)
-	issue, err := c.Issue(id)
-	if err != nil {
-		fatal("Failed to update issue: %s\n", err)
-	}
-	is, err := c.IssueStatuses()
+	c := lkredmine.NewClient(conf.Endpoint, conf.Apikey)
+	allowed, err := c.AllowedStatuses(id)
 	if err != nil {
-		fatal("Failed to get issue statuses: %s\n", err)
+		fatal("Failed to get allowed statuses: %s\n", err)
 	}
-	for _, s := range is {
+	for _, s := range allowed {
 		if s.IsClosed {
-			issue.StatusId = s.Id
-			err = c.UpdateIssue(*issue)
+			err = c.TransitionIssue(id, s.Name, "")
 			if err != nil {
 				fatal("Failed to update issue: %s\n", err)
 			}
-			break
+			return
 		}
 	}
+	fatal("No closed status allowed for issue: %s\n", fmt.Errorf("#%d", id))
 }
 
 func notesIssue(id int) {
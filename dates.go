@@ -0,0 +1,121 @@
+package redmine
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// DateLayout is the layout Redmine uses for dates such as start_date, due_date or spent_on.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without time of day. The zero Date is encoded as null.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date for the given year, month and day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+// ParseDate parses a date formatted as YYYY-MM-DD. An empty string yields the zero Date.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+// String returns the date formatted as YYYY-MM-DD, or an empty string for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Timestamp is a point in time such as created_on or updated_on. The zero Timestamp is encoded as null.
+// A Timestamp decoded from a plain date is encoded as a plain date again.
+type Timestamp struct {
+	time.Time
+	dateOnly bool
+}
+
+// String returns the timestamp formatted as RFC 3339, or as YYYY-MM-DD when it was
+// decoded from a plain date, or an empty string for the zero Timestamp.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	if t.dateOnly {
+		return t.Format(DateLayout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*t = Timestamp{}
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err == nil {
+		*t = Timestamp{Time: parsed}
+		return nil
+	}
+	// some plugins and older versions send plain dates
+	if parsed, dateErr := time.Parse(DateLayout, s); dateErr == nil {
+		*t = Timestamp{Time: parsed, dateOnly: true}
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package redmine
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Enumeration types served under /enumerations. Plugins may register more.
+const (
+	EnumerationIssuePriorities     string = "issue_priorities"
+	EnumerationTimeEntryActivities string = "time_entry_activities"
+	EnumerationDocumentCategories  string = "document_categories"
+)
+
+type Enumeration struct {
+	Id           int            `json:"id"`
+	Name         string         `json:"name"`
+	IsDefault    bool           `json:"is_default"`
+	Active       bool           `json:"active"`
+	Position     int            `json:"position,omitempty"`
+	CustomFields []*CustomField `json:"custom_fields,omitempty"`
+}
+
+// CustomFieldByName returns the custom field with the given name, or nil.
+func (e *Enumeration) CustomFieldByName(name string) *CustomField {
+	return customFieldByName(e.CustomFields, name)
+}
+
+// Enumerations fetches the values of the given enumeration type, e.g. EnumerationDocumentCategories.
+func (c *Client) Enumerations(enumerationType string) ([]Enumeration, error) {
+	res, err := c.Get(c.endpoint + "/enumerations/" + url.PathEscape(enumerationType) + ".json?key=" + c.apikey + c.getPaginationClause())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r map[string][]Enumeration
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.StatusCode != 200 {
+		err = errorFromResp(decoder, res.StatusCode)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r[enumerationType], nil
+}
+
+// DocumentCategories fetches the document categories.
+func (c *Client) DocumentCategories() ([]Enumeration, error) {
+	return c.Enumerations(EnumerationDocumentCategories)
+}
@@ -0,0 +1,101 @@
+package redmine
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// knownFields caches, per struct type, the set of JSON keys mapped to its fields,
+// so that reflection runs once per type rather than on every (un)marshal.
+var knownFields sync.Map // reflect.Type -> map[string]bool
+
+func jsonFieldsOf(t reflect.Type) map[string]bool {
+	if fields, ok := knownFields.Load(t); ok {
+		return fields.(map[string]bool)
+	}
+	fields := make(map[string]bool)
+	collectJSONFields(t, fields)
+	knownFields.Store(t, fields)
+	return fields
+}
+
+func collectJSONFields(t reflect.Type, fields map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := removeAfterComma(tag)
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectJSONFields(field.Type, fields)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+}
+
+// unmarshalExtra decodes data into v, a pointer to an alias of a model struct,
+// and returns the keys of data that v has no field for.
+func unmarshalExtra(data []byte, v interface{}) (map[string]interface{}, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	known := jsonFieldsOf(reflect.TypeOf(v).Elem())
+	extra := make(map[string]interface{})
+	for key, value := range raw {
+		if known[key] {
+			continue
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return nil, err
+		}
+		extra[key] = decoded
+	}
+	return extra, nil
+}
+
+// marshalExtra encodes v, an alias of a model struct, merging the extra keys into the result.
+func marshalExtra(v interface{}, extra map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = encoded
+	}
+	return json.Marshal(merged)
+}
+
+// marshalWithout encodes v, a JSON object, dropping the given keys.
+func marshalWithout(v interface{}, keys ...string) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		delete(fields, key)
+	}
+	return json.Marshal(fields)
+}
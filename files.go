@@ -0,0 +1,103 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type filesResult struct {
+	Files []File `json:"files"`
+}
+
+type fileRequest struct {
+	File fileToCreate `json:"file"`
+}
+
+type fileToCreate struct {
+	Token       string `json:"token"`
+	VersionId   int    `json:"version_id,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// File is a downloadable file published with the Files module of a project.
+type File struct {
+	Id          int       `json:"id"`
+	Filename    string    `json:"filename"`
+	Filesize    int       `json:"filesize"`
+	ContentType string    `json:"content_type"`
+	Description string    `json:"description"`
+	ContentURL  string    `json:"content_url"`
+	Author      *IdName   `json:"author"`
+	CreatedOn   Timestamp `json:"created_on"`
+	Version     *IdName   `json:"version"`
+	Digest      string    `json:"digest"`
+	Downloads   int       `json:"downloads"`
+}
+
+// ProjectFiles lists the files published on the given project.
+func (c *Client) ProjectFiles(projectId int) ([]File, error) {
+	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/files.json?key=" + c.apikey)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r filesResult
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.StatusCode != 200 {
+		err = errorFromResp(decoder, res.StatusCode)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.Files, nil
+}
+
+// CreateProjectFile publishes a file previously sent with Upload on the given project.
+// A versionId of 0 leaves the file unattached to any version.
+func (c *Client) CreateProjectFile(projectId int, upload *Upload, versionId int, description string, userName ...string) error {
+	if upload == nil || upload.Token == "" {
+		return errors.New("upload token is required")
+	}
+	var fr fileRequest
+	fr.File = fileToCreate{
+		Token:       upload.Token,
+		VersionId:   versionId,
+		Filename:    upload.Filename,
+		Description: description,
+	}
+	s, err := json.Marshal(fr)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/files.json?key="+c.apikey, strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		err = errorFromResp(json.NewDecoder(res.Body), res.StatusCode)
+	}
+	return err
+}
@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CycleError is returned when an ordering is requested for a graph with cycles.
+type CycleError struct {
+	Cycles [][]int
+}
+
+func (e *CycleError) Error() string {
+	cycles := make([]string, len(e.Cycles))
+	for i, cycle := range e.Cycles {
+		ids := make([]string, len(cycle))
+		for j, id := range cycle {
+			ids[j] = "#" + strconv.Itoa(id)
+		}
+		cycles[i] = strings.Join(ids, " -> ")
+	}
+	return fmt.Sprintf("graph: dependency cycle: %s", strings.Join(cycles, "; "))
+}
+
+// Cycles returns the groups of issues that depend on each other, each group
+// ordered by id. It uses Tarjan's strongly connected components algorithm.
+func (g *Graph) Cycles() [][]int {
+	out := g.successors()
+	index := make(map[int]int)
+	low := make(map[int]int)
+	onStack := make(map[int]bool)
+	var stack []int
+	var cycles [][]int
+
+	var visit func(id int)
+	visit = func(id int) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, e := range out[id] {
+			if _, seen := index[e.To]; !seen {
+				visit(e.To)
+				if low[e.To] < low[id] {
+					low[id] = low[e.To]
+				}
+			} else if onStack[e.To] && index[e.To] < low[id] {
+				low[id] = index[e.To]
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var component []int
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.hasEdge(id, id) {
+			sort.Ints(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, n := range g.Nodes() {
+		if _, seen := index[n.Id]; !seen {
+			visit(n.Id)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+func (g *Graph) hasEdge(from, to int) bool {
+	_, ok := g.edges[[2]int{from, to}]
+	return ok
+}
+
+// TopologicalOrder returns the issue ids so that every issue comes after the
+// issues it depends on. Ties are broken by id. A *CycleError is returned when
+// the graph has cycles.
+func (g *Graph) TopologicalOrder() ([]int, error) {
+	out := g.successors()
+	inDegree := make(map[int]int)
+	for _, e := range g.edges {
+		inDegree[e.To]++
+	}
+	var ready []int
+	for _, n := range g.Nodes() {
+		if inDegree[n.Id] == 0 {
+			ready = append(ready, n.Id)
+		}
+	}
+	order := make([]int, 0, len(g.nodes))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		for _, e := range out[id] {
+			inDegree[e.To]--
+			if inDegree[e.To] == 0 {
+				ready = append(ready, e.To)
+			}
+		}
+	}
+	if len(order) != len(g.nodes) {
+		return nil, &CycleError{Cycles: g.Cycles()}
+	}
+	return order, nil
+}
+
+// Path is a chain of dependent issues.
+type Path struct {
+	Issues []int
+	Hours  float64 // estimated hours of the issues plus the delays between them
+}
+
+// CriticalPath returns the longest chain of dependencies, weighting each issue
+// by its estimated hours and each precedes delay by hoursPerDay hours per day.
+func (g *Graph) CriticalPath(hoursPerDay float64) (*Path, error) {
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+	out := g.successors()
+	finish := make(map[int]float64) // longest duration of a chain ending with the issue
+	prev := make(map[int]int)
+	for _, id := range order {
+		finish[id] += g.nodes[id].EstimatedHours
+	}
+	for _, id := range order {
+		for _, e := range out[id] {
+			candidate := finish[id] + float64(e.Delay)*hoursPerDay + g.nodes[e.To].EstimatedHours
+			if candidate > finish[e.To] {
+				finish[e.To] = candidate
+				prev[e.To] = id
+			}
+		}
+	}
+
+	path := &Path{}
+	if len(order) == 0 {
+		return path, nil
+	}
+	last := order[0]
+	for _, id := range order {
+		if finish[id] > finish[last] {
+			last = id
+		}
+	}
+	path.Hours = finish[last]
+	for id, ok := last, true; ok; id, ok = prev[id] {
+		path.Issues = append([]int{id}, path.Issues...)
+	}
+	return path, nil
+}
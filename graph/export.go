@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (n *Node) label() string {
+	if n.Subject == "" {
+		return fmt.Sprintf("#%d", n.Id)
+	}
+	return fmt.Sprintf("#%d %s", n.Id, n.Subject)
+}
+
+func (e Edge) label() string {
+	if e.Delay != 0 {
+		return fmt.Sprintf("%s +%dd", e.Type, e.Delay)
+	}
+	return e.Type
+}
+
+// DOT renders the graph in the Graphviz DOT language.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph issues {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range g.Nodes() {
+		fmt.Fprintf(&b, "  %d [label=%s];\n", n.Id, dotQuote(n.label()))
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(&b, "  %d -> %d [label=%s];\n", e.From, e.To, dotQuote(e.label()))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote quotes s as a DOT string. Only quotes and backslashes are escaped:
+// Go escapes such as \u00e9 would be shown verbatim by Graphviz.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes() {
+		fmt.Fprintf(&b, "  i%d[\"%s\"]\n", n.Id, strings.Replace(n.label(), `"`, "#quot;", -1))
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(&b, "  i%d -->|%s| i%d\n", e.From, e.label(), e.To)
+	}
+	return b.String()
+}
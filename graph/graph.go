@@ -0,0 +1,197 @@
+// Package graph builds dependency graphs from Redmine issue relations.
+//
+// Only relations that order issues are kept: "blocks" and "precedes" (and their
+// inverses "blocked" and "follows"). An edge From -> To means that From has to be
+// finished before To can start.
+package graph
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	redmine "github.com/LekoLabs/go-redmine"
+)
+
+// Source fetches issues. *redmine.Client implements it.
+type Source interface {
+	IssueWithArgs(id int, args map[string]string) (*redmine.Issue, error)
+	IssuesByFilter(f *redmine.IssueFilter) ([]redmine.Issue, error)
+}
+
+type Node struct {
+	Id             int
+	Subject        string
+	Status         string
+	EstimatedHours float64
+}
+
+// Edge is a dependency between two issues. Delay is the number of days required
+// between the end of From and the start of To, as set on precedes relations.
+type Edge struct {
+	From  int
+	To    int
+	Type  string // redmine.RelationBlocks or redmine.RelationPrecedes
+	Delay int
+}
+
+// Graph is a directed dependency graph of issues.
+type Graph struct {
+	nodes map[int]*Node
+	edges map[[2]int]Edge
+}
+
+func New() *Graph {
+	return &Graph{nodes: make(map[int]*Node), edges: make(map[[2]int]Edge)}
+}
+
+// AddNode adds or replaces the node with n.Id.
+func (g *Graph) AddNode(n Node) {
+	g.nodes[n.Id] = &n
+}
+
+// AddEdge adds a dependency, adding its endpoints as bare nodes when missing.
+// When both blocks and precedes relations link the same issues, the edge with the
+// longest delay is kept.
+func (g *Graph) AddEdge(e Edge) {
+	for _, id := range []int{e.From, e.To} {
+		if _, ok := g.nodes[id]; !ok {
+			g.nodes[id] = &Node{Id: id}
+		}
+	}
+	key := [2]int{e.From, e.To}
+	if old, ok := g.edges[key]; ok && old.Delay >= e.Delay {
+		return
+	}
+	g.edges[key] = e
+}
+
+// Node returns the node with the given id, or nil.
+func (g *Graph) Node(id int) *Node {
+	return g.nodes[id]
+}
+
+// Nodes returns the nodes ordered by id.
+func (g *Graph) Nodes() []*Node {
+	nodes := make([]*Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Id < nodes[j].Id })
+	return nodes
+}
+
+// Edges returns the edges ordered by From then To.
+func (g *Graph) Edges() []Edge {
+	edges := make([]Edge, 0, len(g.edges))
+	for _, e := range g.edges {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// successors returns the edges leaving each node, ordered by target id.
+func (g *Graph) successors() map[int][]Edge {
+	out := make(map[int][]Edge)
+	for _, e := range g.Edges() {
+		out[e.From] = append(out[e.From], e)
+	}
+	return out
+}
+
+// EdgeFromRelation converts a relation into a dependency edge. ok is false for
+// relations that do not order issues, such as relates or duplicates.
+func EdgeFromRelation(r redmine.IssueRelation) (e Edge, ok bool) {
+	delay := 0
+	if r.Delay != nil {
+		delay = *r.Delay
+	}
+	switch r.RelationType {
+	case redmine.RelationBlocks:
+		return Edge{From: r.IssueId, To: r.IssueToId, Type: redmine.RelationBlocks}, true
+	case redmine.RelationBlocked:
+		return Edge{From: r.IssueToId, To: r.IssueId, Type: redmine.RelationBlocks}, true
+	case redmine.RelationPrecedes:
+		return Edge{From: r.IssueId, To: r.IssueToId, Type: redmine.RelationPrecedes, Delay: delay}, true
+	case redmine.RelationFollows:
+		return Edge{From: r.IssueToId, To: r.IssueId, Type: redmine.RelationPrecedes, Delay: delay}, true
+	}
+	return Edge{}, false
+}
+
+// CrawlOptions limits a crawl. A MaxIssues of zero or less means no limit.
+type CrawlOptions struct {
+	MaxIssues int
+}
+
+// ErrTooManyIssues is returned by Crawl when the graph grows beyond CrawlOptions.MaxIssues.
+var ErrTooManyIssues = errors.New("graph: too many issues")
+
+// Crawl fetches the seed issues and follows their blocks and precedes relations
+// transitively, in both directions.
+func Crawl(src Source, seeds []int, opts CrawlOptions) (*Graph, error) {
+	g := New()
+	queue := append([]int(nil), seeds...)
+	fetched := make(map[int]bool)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if fetched[id] {
+			continue
+		}
+		if opts.MaxIssues > 0 && len(fetched) >= opts.MaxIssues {
+			return nil, ErrTooManyIssues
+		}
+		fetched[id] = true
+
+		issue, err := src.IssueWithArgs(id, map[string]string{"include": "relations"})
+		if err != nil {
+			return nil, err
+		}
+		g.AddNode(nodeOf(issue))
+		for _, r := range issue.Relations {
+			e, ok := EdgeFromRelation(r)
+			if !ok {
+				continue
+			}
+			g.AddEdge(e)
+			for _, next := range []int{e.From, e.To} {
+				if !fetched[next] {
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+// CrawlVersion crawls from every issue, open or closed, targeted at the given version.
+// Issues of other versions reached through relations are included as well.
+func CrawlVersion(src Source, versionId int, opts CrawlOptions) (*Graph, error) {
+	issues, err := src.IssuesByFilter(&redmine.IssueFilter{
+		StatusId:     "*",
+		ExtraFilters: map[string]string{"fixed_version_id": strconv.Itoa(versionId)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	seeds := make([]int, len(issues))
+	for i, issue := range issues {
+		seeds[i] = issue.Id
+	}
+	return Crawl(src, seeds, opts)
+}
+
+func nodeOf(issue *redmine.Issue) Node {
+	n := Node{Id: issue.Id, Subject: issue.Subject, EstimatedHours: float64(issue.EstimatedHours)}
+	if issue.Status != nil {
+		n.Status = issue.Status.Name
+	}
+	return n
+}
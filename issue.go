@@ -1,11 +1,11 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"reflect"
 	"strconv"
 	"strings"
 )
@@ -32,146 +32,266 @@ type JournalDetails struct {
 	NewValue string `json:"new_value"`
 }
 type Journal struct {
-	Id        int              `json:"id"`
-	User      *IdName          `json:"user"`
-	Notes     string           `json:"notes"`
-	CreatedOn string           `json:"created_on"`
-	Details   []JournalDetails `json:"details"`
+	Id           int              `json:"id"`
+	User         *IdName          `json:"user"`
+	Notes        string           `json:"notes"`
+	PrivateNotes bool             `json:"private_notes,omitempty"`
+	CreatedOn    Timestamp        `json:"created_on"`
+	Details      []JournalDetails `json:"details"`
+}
+
+// IssueChild is a subtask as listed with include=children.
+type IssueChild struct {
+	Id       int           `json:"id"`
+	Tracker  *IdName       `json:"tracker"`
+	Subject  string        `json:"subject"`
+	Children []*IssueChild `json:"children,omitempty"`
 }
 
 type IssueCreationRequest struct {
 	Issue IssueToCreate `json:"issue"`
 }
 
+// IssueToCreate holds the writable attributes of an issue for CreateIssue and UpdateIssue.
+// Attributes left at their zero value are not sent, except the pointer fields,
+// which are sent whenever they are not nil, so that e.g. a zero EstimatedHours or
+// a false IsPrivate can be set. Use an IssuePatch to clear an attribute.
 type IssueToCreate struct {
 	Id             int            `json:"id,omitempty"`
-	ProjectId      int            `json:"project_id"`
-	TrackerId      int            `json:"tracker_id"`
+	ProjectId      int            `json:"project_id,omitempty"`
+	TrackerId      int            `json:"tracker_id,omitempty"`
 	StatusId       int            `json:"status_id,omitempty"`
 	PriorityId     int            `json:"priority_id,omitempty"`
-	Subject        string         `json:"subject"`
-	Description    string         `json:"description"`
+	Subject        string         `json:"subject,omitempty"`
+	Description    string         `json:"description,omitempty"`
 	CategoryId     int            `json:"category_id,omitempty"`
 	FixedVersionId int            `json:"fixed_version_id,omitempty"`
 	AssignedToId   int            `json:"assigned_to_id,omitempty"`
 	ParentIssueId  int            `json:"parent_issue_id,omitempty"`
 	CustomFields   []*CustomField `json:"custom_fields,omitempty"`
 	WatcherUserIds []int          `json:"watcher_user_ids,omitempty"`
-	IsPrivate      bool           `json:"is_private"`
-	EstimatedHours float32        `json:"estimated_hours,omitempty"`
-	Notes          string         `json:"notes"` // Notes about the updates
+	IsPrivate      *bool          `json:"is_private,omitempty"`
+	StartDate      *Date          `json:"start_date,omitempty"`
+	DueDate        *Date          `json:"due_date,omitempty"`
+	EstimatedHours *float32       `json:"estimated_hours,omitempty"`
+	DoneRatio      *int           `json:"done_ratio,omitempty"`
+	Uploads        []*Upload      `json:"uploads,omitempty"`
+	Notes          string         `json:"notes,omitempty"` // Notes about the updates
+	PrivateNotes   bool           `json:"private_notes,omitempty"`
 }
 
-type Issue struct {
-	Id             int                    `json:"id"`
-	Subject        string                 `json:"subject"`
-	Description    string                 `json:"description"`
-	Project        *IdName                `json:"project"`
-	Tracker        *IdName                `json:"tracker"`
-	Parent         *Id                    `json:"parent"`
-	Status         *IdName                `json:"status"`
-	Priority       *IdName                `json:"priority"`
-	Author         *IdName                `json:"author"`
-	FixedVersion   *IdName                `json:"fixed_version"`
-	AssignedTo     *IdName                `json:"assigned_to"`
-	Category       *IdName                `json:"category"`
-	Notes          string                 `json:"notes"`
-	StatusDate     string                 `json:"status_date"`
-	CreatedOn      string                 `json:"created_on"`
-	UpdatedOn      string                 `json:"updated_on"`
-	StartDate      string                 `json:"start_date"`
-	DueDate        string                 `json:"due_date"`
-	ClosedOn       string                 `json:"closed_on"`
-	CustomFields   []*CustomField         `json:"custom_fields,omitempty"`
-	Uploads        []*Upload              `json:"uploads"`
-	DoneRatio      float32                `json:"done_ratio"`
-	EstimatedHours float32                `json:"estimated_hours"`
-	IsPrivate      bool                   `json:"is_private"`
-	Journals       []*Journal             `json:"journals"`
-	Extra          map[string]interface{} `json:"-"`
+// ToUpdate converts the issue into an IssueToCreate carrying all of its
+// writable attributes, so that it can be modified and sent back with UpdateIssue.
+// Watchers are only known when the issue was fetched with include=watchers;
+// Redmine only honors WatcherUserIds when creating an issue.
+func (issue *Issue) ToUpdate() IssueToCreate {
+	update := IssueToCreate{
+		Id:          issue.Id,
+		Subject:     issue.Subject,
+		Description: issue.Description,
+	}
+	if issue.Project != nil {
+		update.ProjectId = issue.Project.Id
+	}
+	if issue.Tracker != nil {
+		update.TrackerId = issue.Tracker.Id
+	}
+	if issue.Status != nil {
+		update.StatusId = issue.Status.Id
+	}
+	if issue.Priority != nil {
+		update.PriorityId = issue.Priority.Id
+	}
+	if issue.Category != nil {
+		update.CategoryId = issue.Category.Id
+	}
+	if issue.FixedVersion != nil {
+		update.FixedVersionId = issue.FixedVersion.Id
+	}
+	if issue.AssignedTo != nil {
+		update.AssignedToId = issue.AssignedTo.Id
+	}
+	if issue.Parent != nil {
+		update.ParentIssueId = issue.Parent.Id
+	}
+	for _, cf := range issue.CustomFields {
+		if cf != nil {
+			update.CustomFields = append(update.CustomFields, &CustomField{Id: cf.Id, Name: cf.Name, Multiple: cf.Multiple, Value: copyCustomFieldValue(cf.Value)})
+		}
+	}
+	for _, w := range issue.Watchers {
+		update.WatcherUserIds = append(update.WatcherUserIds, w.Id)
+	}
+	isPrivate := issue.IsPrivate
+	update.IsPrivate = &isPrivate
+	if !issue.StartDate.IsZero() {
+		startDate := issue.StartDate
+		update.StartDate = &startDate
+	}
+	if !issue.DueDate.IsZero() {
+		dueDate := issue.DueDate
+		update.DueDate = &dueDate
+	}
+	if issue.EstimatedHours != 0 {
+		estimatedHours := issue.EstimatedHours
+		update.EstimatedHours = &estimatedHours
+	}
+	doneRatio := int(issue.DoneRatio)
+	update.DoneRatio = &doneRatio
+	return update
 }
 
-func (issue Issue) MarshalJSON() ([]byte, error) {
-	type Issue2 Issue
+// Diff returns a patch holding the writable attributes that differ between the
+// issue and modified, typically a copy of it changed in a read-modify-write loop.
+// References and dates removed in modified are cleared; custom fields are
+// compared by id.
+func (issue *Issue) Diff(modified *Issue) *IssuePatch {
+	p := NewIssuePatch()
+	before, after := issue.ToUpdate(), modified.ToUpdate()
+
+	diffRequiredId(p, "project_id", before.ProjectId, after.ProjectId)
+	diffRequiredId(p, "tracker_id", before.TrackerId, after.TrackerId)
+	diffRequiredId(p, "status_id", before.StatusId, after.StatusId)
+	diffRequiredId(p, "priority_id", before.PriorityId, after.PriorityId)
+	diffOptionalId(p, "category_id", before.CategoryId, after.CategoryId)
+	diffOptionalId(p, "fixed_version_id", before.FixedVersionId, after.FixedVersionId)
+	diffOptionalId(p, "assigned_to_id", before.AssignedToId, after.AssignedToId)
+	diffOptionalId(p, "parent_issue_id", before.ParentIssueId, after.ParentIssueId)
+	if before.Subject != after.Subject {
+		p.SetSubject(after.Subject)
+	}
+	if before.Description != after.Description {
+		p.SetDescription(after.Description)
+	}
+	if issue.IsPrivate != modified.IsPrivate {
+		p.SetIsPrivate(modified.IsPrivate)
+	}
+	diffDate(p, "start_date", issue.StartDate, modified.StartDate)
+	diffDate(p, "due_date", issue.DueDate, modified.DueDate)
+	if issue.EstimatedHours != modified.EstimatedHours {
+		if modified.EstimatedHours == 0 {
+			p.ClearEstimatedHours()
+		} else {
+			p.SetEstimatedHours(modified.EstimatedHours)
+		}
+	}
+	if *before.DoneRatio != *after.DoneRatio {
+		p.SetDoneRatio(*after.DoneRatio)
+	}
 
-	// To reset parent issue, set empty string to "parent_issue_id"
-	var parentIssueID *string
-	if issue.Parent == nil {
-		// reset parent issue
-		id := ""
-		parentIssueID = &id
-	}
-
-	// Marshal the main struct without the Extra fields
-	aux, err := json.Marshal(&struct {
-		Issue2
-		ParentId *string `json:"parent_issue_id,omitempty"`
-	}{
-		Issue2:   Issue2(issue),
-		ParentId: parentIssueID,
-	})
-	if err != nil {
-		return nil, err
+	for _, cf := range after.CustomFields {
+		old := customFieldById(before.CustomFields, cf.Id)
+		if old == nil || !equalStrings(old.Values(), cf.Values()) {
+			p.SetCustomField(cf.Id, cf.Value)
+		}
+	}
+	for _, cf := range before.CustomFields {
+		if customFieldById(after.CustomFields, cf.Id) == nil && len(cf.Values()) > 0 {
+			p.ClearCustomField(cf.Id)
+		}
 	}
+	return p
+}
 
-	// Marshal the Extra fields
-	extraData, err := json.Marshal(issue.Extra)
-	if err != nil {
-		return nil, err
+func diffRequiredId(p *IssuePatch, key string, before, after int) {
+	if after != 0 && before != after {
+		p.Set(key, after)
+	}
+}
+
+func diffOptionalId(p *IssuePatch, key string, before, after int) {
+	if before == after {
+		return
+	}
+	if after == 0 {
+		p.Clear(key)
+	} else {
+		p.Set(key, after)
 	}
+}
 
-	// Unmarshal both into maps
-	var auxMap map[string]interface{}
-	var extraMap map[string]interface{}
+func diffDate(p *IssuePatch, key string, before, after Date) {
+	if before.Equal(after.Time) {
+		return
+	}
+	if after.IsZero() {
+		p.Clear(key)
+	} else {
+		p.Set(key, after)
+	}
+}
 
-	if err := json.Unmarshal(aux, &auxMap); err != nil {
-		return nil, err
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
 	}
-	if err := json.Unmarshal(extraData, &extraMap); err != nil {
-		return nil, err
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
 	}
+	return true
+}
 
-	// Merge the Extra fields into the main struct map
-	for key, value := range extraMap {
-		auxMap[key] = value
+func copyCustomFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return append([]interface{}(nil), v...)
+	case []string:
+		return append([]string(nil), v...)
 	}
+	return value
+}
+
+type Issue struct {
+	Id                  int                    `json:"id"`
+	Subject             string                 `json:"subject"`
+	Description         string                 `json:"description"`
+	Project             *IdName                `json:"project"`
+	Tracker             *IdName                `json:"tracker"`
+	Parent              *Id                    `json:"parent"`
+	Status              *IdName                `json:"status"`
+	Priority            *IdName                `json:"priority"`
+	Author              *IdName                `json:"author"`
+	FixedVersion        *IdName                `json:"fixed_version"`
+	AssignedTo          *IdName                `json:"assigned_to"`
+	Category            *IdName                `json:"category"`
+	Notes               string                 `json:"notes"`
+	StatusDate          Date                   `json:"status_date"`
+	CreatedOn           Timestamp              `json:"created_on"`
+	UpdatedOn           Timestamp              `json:"updated_on"`
+	StartDate           Date                   `json:"start_date"`
+	DueDate             Date                   `json:"due_date"`
+	ClosedOn            Timestamp              `json:"closed_on"`
+	CustomFields        []*CustomField         `json:"custom_fields,omitempty"`
+	Uploads             []*Upload              `json:"uploads"`
+	DoneRatio           float32                `json:"done_ratio"`
+	EstimatedHours      float32                `json:"estimated_hours"`
+	SpentHours          float32                `json:"spent_hours,omitempty"`
+	TotalEstimatedHours float32                `json:"total_estimated_hours,omitempty"`
+	TotalSpentHours     float32                `json:"total_spent_hours,omitempty"`
+	IsPrivate           bool                   `json:"is_private"`
+	Journals            []*Journal             `json:"journals"`
+	AllowedStatuses     []IssueStatus          `json:"allowed_statuses,omitempty"`
+	Children            []*IssueChild          `json:"children,omitempty"`
+	Changesets          []Changeset            `json:"changesets,omitempty"`
+	Relations           []IssueRelation        `json:"relations,omitempty"`
+	Watchers            []IdName               `json:"watchers,omitempty"`
+	Extra               map[string]interface{} `json:"-"`
+}
 
-	// Marshal the final map back to JSON
-	return json.Marshal(auxMap)
+func (issue Issue) MarshalJSON() ([]byte, error) {
+	type Issue2 Issue
+	return marshalExtra(Issue2(issue), issue.Extra)
 }
 
 func (issue *Issue) UnmarshalJSON(data []byte) error {
 	// Create an alias type to avoid infinite recursion
 	type Alias Issue
-	aux := &struct {
-		*Alias
-	}{
-		Alias: (*Alias)(issue),
-	}
-
-	// Unmarshal known fields
-	if err := json.Unmarshal(data, &aux); err != nil {
-		return err
-	}
-
-	// Unmarshal all fields into a map
-	extra := make(map[string]interface{})
-	if err := json.Unmarshal(data, &extra); err != nil {
+	extra, err := unmarshalExtra(data, (*Alias)(issue))
+	if err != nil {
 		return err
 	}
-
-	// Use reflection to iterate over the struct fields and remove known fields from the map
-	val := reflect.ValueOf(issue).Elem()
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Type().Field(i)
-		jsonTag := field.Tag.Get("json")
-		// Remove everything after a comma (if the comma exists)
-		jsonTag = removeAfterComma(jsonTag)
-		if jsonTag != "" && jsonTag != "-" {
-			delete(extra, jsonTag)
-		}
-	}
-
 	issue.Extra = extra
 	return nil
 }
@@ -241,6 +361,10 @@ func (c *Client) Issues() ([]Issue, error) {
 }
 
 func (c *Client) CreateIssue(issueToCreate IssueToCreate, userName ...string) (*Issue, error) {
+	return c.createIssue(context.Background(), issueToCreate, userName...)
+}
+
+func (c *Client) createIssue(ctx context.Context, issueToCreate IssueToCreate, userName ...string) (*Issue, error) {
 	var ir IssueCreationRequest
 	ir.Issue = issueToCreate
 	s, err := json.Marshal(ir)
@@ -248,13 +372,13 @@ func (c *Client) CreateIssue(issueToCreate IssueToCreate, userName ...string) (*
 		return nil, err
 	}
 	ss := string(s)
-	req, err := http.NewRequest("POST", c.endpoint+"/issues.json?key="+c.apikey, strings.NewReader(ss))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/issues.json?key="+c.apikey, strings.NewReader(ss))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -279,24 +403,91 @@ func (c *Client) CreateIssue(issueToCreate IssueToCreate, userName ...string) (*
 	return &r.Issue, nil
 }
 
+// UpdateIssue sends the attributes of issue that are not at their zero value and
+// the pointer fields that are not nil; the other attributes are left untouched.
+// As a consequence an attribute cannot be cleared, nor a plain field such as
+// Subject set to an empty value, through UpdateIssue: use PatchIssue with the
+// Clear methods of IssuePatch for that.
 func (c *Client) UpdateIssue(issue IssueToCreate, userName ...string) error {
 	var ir IssueCreationRequest
 	ir.Issue = issue
-	s, err := json.Marshal(ir)
+	return c.putIssue(issue.Id, ir, userName...)
+}
+
+// AllowedStatuses returns the statuses the current user may move the issue to,
+// according to the workflow. Requires Redmine 5.0 or later.
+func (c *Client) AllowedStatuses(issueId int) ([]IssueStatus, error) {
+	issue, err := getOneIssue(c, issueId, map[string]string{"include": "allowed_statuses"})
+	if err != nil {
+		return nil, err
+	}
+	if issue.AllowedStatuses == nil {
+		return nil, errors.New("allowed_statuses not supported by this Redmine version")
+	}
+	return issue.AllowedStatuses, nil
+}
+
+// TransitionIssue moves the issue to the status with the given name, adding notes
+// when not empty. The target status is checked against the workflow first so that
+// a disallowed transition fails instead of being silently ignored by Redmine.
+func (c *Client) TransitionIssue(issueId int, statusName string, notes string, userName ...string) error {
+	allowed, err := c.AllowedStatuses(issueId)
+	if err != nil {
+		return err
+	}
+	status := findIssueStatus(allowed, statusName)
+	if status == nil {
+		names := make([]string, len(allowed))
+		for i, s := range allowed {
+			names[i] = s.Name
+		}
+		return fmt.Errorf("transition of issue #%d to %q is not allowed (allowed: %s)", issueId, statusName, strings.Join(names, ", "))
+	}
+	var ir struct {
+		Issue struct {
+			StatusId int    `json:"status_id"`
+			Notes    string `json:"notes,omitempty"`
+		} `json:"issue"`
+	}
+	ir.Issue.StatusId = status.Id
+	ir.Issue.Notes = notes
+	return c.putIssue(issueId, ir, userName...)
+}
+
+func findIssueStatus(statuses []IssueStatus, name string) *IssueStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	for i := range statuses {
+		if strings.EqualFold(statuses[i].Name, name) {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func (c *Client) putIssue(id int, body interface{}, userName ...string) error {
+	return c.putIssueContext(context.Background(), id, body, userName...)
+}
+
+func (c *Client) putIssueContext(ctx context.Context, id int, body interface{}, userName ...string) error {
+	s, err := json.Marshal(body)
 	if err != nil {
 		return err
 	}
 	ss := string(s)
-	req, err := http.NewRequest("PUT", c.endpoint+"/issues/"+strconv.Itoa(issue.Id)+".json?key="+c.apikey, strings.NewReader(ss))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/issues/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(ss))
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
-	
+
 	res, err := c.Do(req)
 	if err != nil {
 		return err
@@ -304,7 +495,7 @@ func (c *Client) UpdateIssue(issue IssueToCreate, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 	if res.StatusCode/100 != 2 {
 		decoder := json.NewDecoder(res.Body)
@@ -327,7 +518,7 @@ func (c *Client) DeleteIssue(id int, userName ...string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -336,7 +527,7 @@ func (c *Client) DeleteIssue(id int, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
@@ -400,20 +591,28 @@ func mapConcat(m map[string]string, delimiter string) string {
 }
 
 func getOneIssue(c *Client, id int, args map[string]string) (*Issue, error) {
+	return getOneIssueContext(context.Background(), c, id, args)
+}
+
+func getOneIssueContext(ctx context.Context, c *Client, id int, args map[string]string) (*Issue, error) {
 	url := c.endpoint + "/issues/" + strconv.Itoa(id) + ".json?key=" + c.apikey
 
 	if args != nil {
 		url += "&" + mapConcat(args, "&")
 	}
 
-	res, err := c.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
@@ -5,15 +5,339 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type customFieldsResult struct {
-	CustomFields []CustomField `json:"custom_fields"`
+	CustomFields []CustomFieldDefinition `json:"custom_fields"`
+}
+
+// CustomFieldPossibleValue is one entry of a list custom field.
+type CustomFieldPossibleValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// CustomFieldDefinition is the administrative definition of a custom field,
+// as opposed to CustomField which carries a value on a customized object.
+type CustomFieldDefinition struct {
+	Id             int                        `json:"id"`
+	Name           string                     `json:"name"`
+	Description    string                     `json:"description"`
+	CustomizedType string                     `json:"customized_type"`
+	FieldFormat    string                     `json:"field_format"`
+	Regexp         string                     `json:"regexp"`
+	MinLength      int                        `json:"min_length"`
+	MaxLength      int                        `json:"max_length"`
+	IsRequired     bool                       `json:"is_required"`
+	IsFilter       bool                       `json:"is_filter"`
+	Searchable     bool                       `json:"searchable"`
+	Multiple       bool                       `json:"multiple"`
+	DefaultValue   string                     `json:"default_value"`
+	Visible        bool                       `json:"visible"`
+	PossibleValues []CustomFieldPossibleValue `json:"possible_values"`
+	Trackers       []IdName                   `json:"trackers"`
+	Roles          []IdName                   `json:"roles"`
+}
+
+// EnabledForTracker reports whether an issue custom field is used by the given tracker.
+func (d *CustomFieldDefinition) EnabledForTracker(trackerId int) bool {
+	for _, t := range d.Trackers {
+		if t.Id == trackerId {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks value against the definition the same way Redmine would
+// on save: presence, multiplicity, length, regexp, format and possible values.
+// value may be a string, a []string, a []interface{} or any scalar.
+// Redmine regexps use Ruby syntax; a regexp that Go cannot compile, e.g. one
+// with possessive quantifiers, is not checked and is left for Redmine to enforce.
+func (d *CustomFieldDefinition) Validate(value interface{}) error {
+	var values []string
+	for _, v := range customFieldStrings(value) {
+		if strings.TrimSpace(v) != "" {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		if d.IsRequired {
+			return fmt.Errorf("custom field %q cannot be blank", d.Name)
+		}
+		return nil
+	}
+	if !d.Multiple && len(values) > 1 {
+		return fmt.Errorf("custom field %q does not accept multiple values", d.Name)
+	}
+
+	var re *regexp.Regexp
+	if d.Regexp != "" {
+		re, _ = regexp.Compile(d.Regexp)
+	}
+	for _, v := range values {
+		if d.MinLength > 0 && len([]rune(v)) < d.MinLength {
+			return fmt.Errorf("custom field %q is too short (minimum is %d characters)", d.Name, d.MinLength)
+		}
+		if d.MaxLength > 0 && len([]rune(v)) > d.MaxLength {
+			return fmt.Errorf("custom field %q is too long (maximum is %d characters)", d.Name, d.MaxLength)
+		}
+		if re != nil && !re.MatchString(v) {
+			return fmt.Errorf("custom field %q is invalid", d.Name)
+		}
+		if err := d.validateFormat(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *CustomFieldDefinition) validateFormat(v string) error {
+	var err error
+	switch d.FieldFormat {
+	case "int", "user", "version":
+		_, err = strconv.Atoi(v)
+	case "float":
+		_, err = strconv.ParseFloat(v, 64)
+	case "date":
+		_, err = time.Parse("2006-01-02", v)
+	case "bool":
+		if v != "0" && v != "1" {
+			err = errors.New("not a boolean")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("custom field %q is not a valid %s", d.Name, d.FieldFormat)
+	}
+	if len(d.PossibleValues) > 0 && (d.FieldFormat == "list" || d.FieldFormat == "enumeration") {
+		for _, pv := range d.PossibleValues {
+			if pv.Value == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("custom field %q is not included in the list", d.Name)
+	}
+	return nil
+}
+
+func customFieldStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		s := make([]string, 0, len(v))
+		for _, e := range v {
+			if e != nil {
+				s = append(s, fmt.Sprint(e))
+			}
+		}
+		return s
+	case bool:
+		if v {
+			return []string{"1"}
+		}
+		return []string{"0"}
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// ErrEmptyCustomField is returned by the typed CustomField accessors when the field has no value.
+var ErrEmptyCustomField = errors.New("custom field has no value")
+
+// Values returns every non-blank value of the field. Single value fields yield at most one element.
+func (cf *CustomField) Values() []string {
+	var values []string
+	for _, v := range customFieldStrings(cf.Value) {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// StringValue returns the value as a string; multiple values are joined with ", ".
+func (cf *CustomField) StringValue() string {
+	return strings.Join(cf.Values(), ", ")
+}
+
+// IntValue parses the value as an integer. Useful for int, user and version fields.
+func (cf *CustomField) IntValue() (int, error) {
+	v, err := cf.singleValue()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(v)
+}
+
+// FloatValue parses the value as a float.
+func (cf *CustomField) FloatValue() (float64, error) {
+	v, err := cf.singleValue()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// BoolValue parses the value of a boolean field, which Redmine encodes as "1" or "0".
+func (cf *CustomField) BoolValue() (bool, error) {
+	v, err := cf.singleValue()
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(v)
+}
+
+// DateValue parses the value of a date field (YYYY-MM-DD).
+func (cf *CustomField) DateValue() (time.Time, error) {
+	v, err := cf.singleValue()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+// IntValues parses every value as an integer, e.g. the user ids of a multiple user field.
+func (cf *CustomField) IntValues() ([]int, error) {
+	values := cf.Values()
+	ids := make([]int, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (cf *CustomField) singleValue() (string, error) {
+	values := cf.Values()
+	if len(values) == 0 {
+		return "", ErrEmptyCustomField
+	}
+	if len(values) > 1 {
+		return "", fmt.Errorf("custom field %q has multiple values", cf.Name)
+	}
+	return values[0], nil
+}
+
+func customFieldByName(fields []*CustomField, name string) *CustomField {
+	for _, cf := range fields {
+		if cf != nil && cf.Name == name {
+			return cf
+		}
+	}
+	return nil
+}
+
+func customFieldById(fields []*CustomField, id int) *CustomField {
+	for _, cf := range fields {
+		if cf != nil && cf.Id == id {
+			return cf
+		}
+	}
+	return nil
+}
+
+func setCustomField(fields *[]*CustomField, id int, value interface{}) {
+	if cf := customFieldById(*fields, id); cf != nil {
+		cf.Value = value
+		return
+	}
+	*fields = append(*fields, &CustomField{Id: id, Value: value})
+}
+
+// CustomFieldByName returns the custom field with the given name, or nil.
+func (issue *Issue) CustomFieldByName(name string) *CustomField {
+	return customFieldByName(issue.CustomFields, name)
+}
+
+// CustomFieldById returns the custom field with the given id, or nil.
+func (issue *Issue) CustomFieldById(id int) *CustomField {
+	return customFieldById(issue.CustomFields, id)
+}
+
+// SetCustomField sets the value of the given custom field, adding it when missing.
+func (issue *Issue) SetCustomField(id int, value interface{}) {
+	setCustomField(&issue.CustomFields, id, value)
+}
+
+// SetCustomField sets the value of the given custom field, adding it when missing.
+func (issue *IssueToCreate) SetCustomField(id int, value interface{}) {
+	setCustomField(&issue.CustomFields, id, value)
+}
+
+// CustomFieldByName returns the custom field with the given name, or nil.
+func (project *Project) CustomFieldByName(name string) *CustomField {
+	return customFieldByName(project.CustomFields, name)
+}
+
+// CustomFieldById returns the custom field with the given id, or nil.
+func (project *Project) CustomFieldById(id int) *CustomField {
+	return customFieldById(project.CustomFields, id)
+}
+
+// SetCustomField sets the value of the given custom field, adding it when missing.
+func (project *Project) SetCustomField(id int, value interface{}) {
+	setCustomField(&project.CustomFields, id, value)
+}
+
+// CustomFieldByName returns the custom field with the given name, or nil.
+func (user *User) CustomFieldByName(name string) *CustomField {
+	return customFieldByName(user.CustomFields, name)
+}
+
+// CustomFieldById returns the custom field with the given id, or nil.
+func (user *User) CustomFieldById(id int) *CustomField {
+	return customFieldById(user.CustomFields, id)
+}
+
+// SetCustomField sets the value of the given custom field, adding it when missing.
+func (user *User) SetCustomField(id int, value interface{}) {
+	setCustomField(&user.CustomFields, id, value)
+}
+
+// CustomFieldByName returns the custom field with the given name, or nil.
+func (version *Version) CustomFieldByName(name string) *CustomField {
+	return customFieldByName(version.CustomFields, name)
+}
+
+// CustomFieldById returns the custom field with the given id, or nil.
+func (version *Version) CustomFieldById(id int) *CustomField {
+	return customFieldById(version.CustomFields, id)
+}
+
+// SetCustomField sets the value of the given custom field, adding it when missing.
+func (version *Version) SetCustomField(id int, value interface{}) {
+	setCustomField(&version.CustomFields, id, value)
+}
+
+// CustomFieldByName returns the custom field with the given name, or nil.
+func (timeEntry *TimeEntry) CustomFieldByName(name string) *CustomField {
+	return customFieldByName(timeEntry.CustomFields, name)
+}
+
+// CustomFieldById returns the custom field with the given id, or nil.
+func (timeEntry *TimeEntry) CustomFieldById(id int) *CustomField {
+	return customFieldById(timeEntry.CustomFields, id)
+}
+
+// SetCustomField sets the value of the given custom field, adding it when missing.
+func (timeEntry *TimeEntry) SetCustomField(id int, value interface{}) {
+	setCustomField(&timeEntry.CustomFields, id, value)
 }
 
 // CustomFields consulta los campos personalizados
-func (c *Client) CustomFields() ([]CustomField, error) {
+func (c *Client) CustomFields() ([]CustomFieldDefinition, error) {
 	req, err := http.NewRequest(
 		"GET",
 		fmt.Sprintf("%s/custom_fields.json?%s",
@@ -3,6 +3,7 @@ package redmine
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,23 +14,72 @@ type issueRelationsResult struct {
 }
 
 type issueRelationResult struct {
-	IssueRelation IssueRelation `json:"issue_relation"`
+	IssueRelation IssueRelation `json:"relation"`
 }
 
 type issueRelationRequest struct {
-	IssueRelation IssueRelation `json:"issue_relation"`
+	IssueRelation IssueRelation `json:"relation"`
 }
 
+// Relation types accepted by Redmine. Each type has an inverse which Redmine
+// reports when the relation is seen from the other issue.
+const (
+	RelationRelates    string = "relates"
+	RelationDuplicates string = "duplicates"
+	RelationDuplicated string = "duplicated"
+	RelationBlocks     string = "blocks"
+	RelationBlocked    string = "blocked"
+	RelationPrecedes   string = "precedes"
+	RelationFollows    string = "follows"
+	RelationCopiedTo   string = "copied_to"
+	RelationCopiedFrom string = "copied_from"
+)
+
+var relationInverses = map[string]string{
+	RelationRelates:    RelationRelates,
+	RelationDuplicates: RelationDuplicated,
+	RelationDuplicated: RelationDuplicates,
+	RelationBlocks:     RelationBlocked,
+	RelationBlocked:    RelationBlocks,
+	RelationPrecedes:   RelationFollows,
+	RelationFollows:    RelationPrecedes,
+	RelationCopiedTo:   RelationCopiedFrom,
+	RelationCopiedFrom: RelationCopiedTo,
+}
+
+// InverseRelationType returns the type of the relation seen from the other issue,
+// e.g. "follows" for "precedes", or an empty string for an unknown type.
+func InverseRelationType(relationType string) string {
+	return relationInverses[relationType]
+}
+
+// IssueRelation links IssueId to IssueToId. Delay is the number of days between
+// the issues and is only meaningful for precedes and follows relations.
 type IssueRelation struct {
-	Id           int    `json:"id"`
-	IssueId      string `json:"issue_id"`
-	IssueToId    string `json:"issue_to_id"`
+	Id           int    `json:"id,omitempty"`
+	IssueId      int    `json:"issue_id,omitempty"`
+	IssueToId    int    `json:"issue_to_id"`
 	RelationType string `json:"relation_type"`
-	Delay        string `json:"delay"`
+	Delay        *int   `json:"delay,omitempty"`
 }
 
+// Validate checks the relation type and that a delay is only given for precedes and follows relations.
+func (r *IssueRelation) Validate() error {
+	if r.IssueToId == 0 {
+		return errors.New("issue_to_id is required")
+	}
+	if _, ok := relationInverses[r.RelationType]; !ok {
+		return fmt.Errorf("unknown relation type %q", r.RelationType)
+	}
+	if r.Delay != nil && r.RelationType != RelationPrecedes && r.RelationType != RelationFollows {
+		return fmt.Errorf("delay is not allowed for %s relations", r.RelationType)
+	}
+	return nil
+}
+
+// IssueRelations lists the relations of the given issue, in both directions.
 func (c *Client) IssueRelations(issueId int) ([]IssueRelation, error) {
-	res, err := c.Get(c.endpoint + "/issue/" + strconv.Itoa(issueId) + "/relations.json?key=" + c.apikey + c.getPaginationClause())
+	res, err := c.Get(c.endpoint + "/issues/" + strconv.Itoa(issueId) + "/relations.json?key=" + c.apikey + c.getPaginationClause())
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +88,7 @@ func (c *Client) IssueRelations(issueId int) ([]IssueRelation, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r issueRelationsResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -65,7 +115,7 @@ func (c *Client) IssueRelation(id int) (*IssueRelation, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r issueRelationResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -82,14 +132,23 @@ func (c *Client) IssueRelation(id int) (*IssueRelation, error) {
 	return &r.IssueRelation, nil
 }
 
+// CreateIssueRelation relates issueRelation.IssueId to issueRelation.IssueToId.
 func (c *Client) CreateIssueRelation(issueRelation IssueRelation, userName ...string) (*IssueRelation, error) {
+	if issueRelation.IssueId == 0 {
+		return nil, errors.New("issue_id is required")
+	}
+	if err := issueRelation.Validate(); err != nil {
+		return nil, err
+	}
 	var ir issueRelationRequest
 	ir.IssueRelation = issueRelation
+	ir.IssueRelation.Id = 0
+	ir.IssueRelation.IssueId = 0
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/relations.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequest("POST", c.endpoint+"/issues/"+strconv.Itoa(issueRelation.IssueId)+"/relations.json?key="+c.apikey, strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +179,9 @@ func (c *Client) CreateIssueRelation(issueRelation IssueRelation, userName ...st
 	return &r.IssueRelation, nil
 }
 
+// UpdateIssueRelation sends the relation to /relations/:id.json.
+//
+// Deprecated: Redmine does not route updates of relations; delete the relation and create it again instead.
 func (c *Client) UpdateIssueRelation(issueRelation IssueRelation, userName ...string) error {
 	var ir issueRelationRequest
 	ir.IssueRelation = issueRelation
@@ -142,7 +204,7 @@ func (c *Client) UpdateIssueRelation(issueRelation IssueRelation, userName ...st
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		decoder := json.NewDecoder(res.Body)
@@ -174,11 +236,11 @@ func (c *Client) DeleteIssueRelation(id int, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
+	if res.StatusCode/100 != 2 {
 		var er errorsResult
 		err = decoder.Decode(&er)
 		if err == nil {
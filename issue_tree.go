@@ -0,0 +1,117 @@
+package redmine
+
+import "math"
+
+// IssueNode is an issue together with its subtasks.
+type IssueNode struct {
+	Issue    *Issue
+	Closed   bool // the issue has a closed status
+	Children []*IssueNode
+}
+
+// IssueTree fetches the issue and, recursively, all of its subtasks.
+// Each issue is fetched at most once, so a corrupted hierarchy that loops
+// back on itself cannot make the crawl run forever.
+func (c *Client) IssueTree(id int) (*IssueNode, error) {
+	statuses, err := c.IssueStatuses()
+	if err != nil {
+		return nil, err
+	}
+	closed := make(map[int]bool)
+	for _, s := range statuses {
+		closed[s.Id] = s.IsClosed
+	}
+	return c.issueTree(id, closed, make(map[int]bool))
+}
+
+func (c *Client) issueTree(id int, closed map[int]bool, visited map[int]bool) (*IssueNode, error) {
+	visited[id] = true
+	issue, err := getOneIssue(c, id, map[string]string{"include": "children"})
+	if err != nil {
+		return nil, err
+	}
+	node := &IssueNode{Issue: issue}
+	if issue.Status != nil {
+		node.Closed = closed[issue.Status.Id]
+	}
+	for _, child := range issue.Children {
+		if child == nil || visited[child.Id] {
+			continue
+		}
+		childNode, err := c.issueTree(child.Id, closed, visited)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, childNode)
+	}
+	return node, nil
+}
+
+// Walk calls fn for the node and all of its descendants, depth first.
+func (n *IssueNode) Walk(fn func(node *IssueNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *IssueNode) walk(fn func(node *IssueNode, depth int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// EstimatedHours returns the estimated hours of the issue and all of its subtasks.
+func (n *IssueNode) EstimatedHours() float32 {
+	total := n.Issue.EstimatedHours
+	for _, child := range n.Children {
+		total += child.EstimatedHours()
+	}
+	return total
+}
+
+// SpentHours returns the hours spent on the issue and all of its subtasks.
+func (n *IssueNode) SpentHours() float32 {
+	total := n.Issue.SpentHours
+	for _, child := range n.Children {
+		total += child.SpentHours()
+	}
+	return total
+}
+
+// DoneRatio returns the done ratio of the issue computed from its subtasks the
+// way Redmine does: an average of the children's done ratios weighted by their
+// estimated hours, rounded down. Closed children count as done whatever their
+// own ratio, and children without an estimate are weighted with the average
+// estimate of their siblings, or equally when none is estimated.
+// Leaf issues report their own done ratio.
+func (n *IssueNode) DoneRatio() float32 {
+	if len(n.Children) == 0 {
+		return n.Issue.DoneRatio
+	}
+
+	var estimated float64
+	var estimatedCount int
+	for _, child := range n.Children {
+		if h := child.EstimatedHours(); h > 0 {
+			estimated += float64(h)
+			estimatedCount++
+		}
+	}
+	average := 1.0
+	if estimatedCount > 0 {
+		average = estimated / float64(estimatedCount)
+	}
+
+	var done float64
+	for _, child := range n.Children {
+		w := float64(child.EstimatedHours())
+		if w <= 0 {
+			w = average
+		}
+		ratio := float64(child.DoneRatio())
+		if child.Closed {
+			ratio = 100
+		}
+		done += ratio * w
+	}
+	return float32(math.Floor(done / (average * float64(len(n.Children)))))
+}
@@ -0,0 +1,61 @@
+package redmine
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type journalRequest struct {
+	Journal journalToUpdate `json:"journal"`
+}
+
+type journalToUpdate struct {
+	Notes string `json:"notes"`
+}
+
+// AddIssueNotes adds a note to the issue. Private notes are only visible to
+// users allowed to view private notes.
+func (c *Client) AddIssueNotes(issueId int, notes string, private bool, userName ...string) error {
+	var ir struct {
+		Issue struct {
+			Notes        string `json:"notes"`
+			PrivateNotes bool   `json:"private_notes,omitempty"`
+		} `json:"issue"`
+	}
+	ir.Issue.Notes = notes
+	ir.Issue.PrivateNotes = private
+	return c.putIssue(issueId, ir, userName...)
+}
+
+// UpdateJournal replaces the notes of an existing journal entry. Requires Redmine 5.0 or later.
+func (c *Client) UpdateJournal(id int, notes string, userName ...string) error {
+	var jr journalRequest
+	jr.Journal.Notes = notes
+	s, err := json.Marshal(jr)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("PUT", c.endpoint+"/journals/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		err = errorFromResp(json.NewDecoder(res.Body), res.StatusCode)
+	}
+	return err
+}
@@ -2,7 +2,6 @@ package redmine
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -21,11 +20,27 @@ type membershipRequest struct {
 }
 
 type Membership struct {
-	Id      int      `json:"id"`
-	Project IdName   `json:"project"`
-	User    IdName   `json:"user"`
-	Roles   []IdName `json:"roles"`
-	Groups  []IdName `json:"groups"`
+	Id      int                    `json:"id"`
+	Project IdName                 `json:"project"`
+	User    IdName                 `json:"user"`
+	Roles   []IdName               `json:"roles"`
+	Groups  []IdName               `json:"groups"`
+	Extra   map[string]interface{} `json:"-"`
+}
+
+func (membership Membership) MarshalJSON() ([]byte, error) {
+	type Alias Membership
+	return marshalExtra(Alias(membership), membership.Extra)
+}
+
+func (membership *Membership) UnmarshalJSON(data []byte) error {
+	type Alias Membership
+	extra, err := unmarshalExtra(data, (*Alias)(membership))
+	if err != nil {
+		return err
+	}
+	membership.Extra = extra
+	return nil
 }
 
 func (c *Client) Memberships(projectId int) ([]Membership, error) {
@@ -38,7 +53,7 @@ func (c *Client) Memberships(projectId int) ([]Membership, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r membershipsResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		err = errorFromResp(decoder, res.StatusCode)
@@ -61,7 +76,7 @@ func (c *Client) Membership(id int) (*Membership, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r membershipResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		err = errorFromResp(decoder, res.StatusCode)
@@ -87,7 +102,7 @@ func (c *Client) CreateMembership(membership Membership, userName ...string) (*M
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -121,7 +136,7 @@ func (c *Client) UpdateMembership(membership Membership, userName ...string) err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -130,7 +145,7 @@ func (c *Client) UpdateMembership(membership Membership, userName ...string) err
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		decoder := json.NewDecoder(res.Body)
@@ -149,7 +164,7 @@ func (c *Client) DeleteMembership(id int, userName ...string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -158,7 +173,7 @@ func (c *Client) DeleteMembership(id int, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
@@ -3,25 +3,82 @@ package redmine
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strconv"
 	"strings"
 )
 
 type newsResult struct {
-	News []News `json:"news"`
+	News       []News `json:"news"`
+	TotalCount int    `json:"total_count"`
+}
+
+type newsItemResult struct {
+	News News `json:"news"`
+}
+
+type newsRequest struct {
+	News newsToSave `json:"news"`
+}
+
+type newsToSave struct {
+	Title       string    `json:"title,omitempty"`
+	Summary     string    `json:"summary"` // always sent so that updates can clear it
+	Description string    `json:"description,omitempty"`
+	Uploads     []*Upload `json:"uploads,omitempty"`
 }
 
 type News struct {
-	Id          int    `json:"id"`
-	Project     IdName `json:"project"`
-	Title       string `json:"title"`
-	Summary     string `json:"summary"`
-	Description string `json:"description"`
-	CreatedOn   string `json:"created_on"`
+	Id          int           `json:"id"`
+	Project     IdName        `json:"project"`
+	Author      *IdName       `json:"author,omitempty"`
+	Title       string        `json:"title"`
+	Summary     string        `json:"summary"`
+	Description string        `json:"description"`
+	CreatedOn   Timestamp     `json:"created_on"`
+	Attachments []*Attachment `json:"attachments,omitempty"`
+	Comments    []NewsComment `json:"comments,omitempty"`
+	Uploads     []*Upload     `json:"uploads,omitempty"` // files to attach on create or update
+}
+
+type NewsComment struct {
+	Id      int     `json:"id"`
+	Author  *IdName `json:"author"`
+	Content string  `json:"content"`
 }
 
+// News lists the news of the given project. All pages are fetched unless
+// the client Limit or Offset is set.
 func (c *Client) News(projectId int) ([]News, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/news.json?key=" + c.apikey + c.getPaginationClause())
+	return c.fetchNews("/projects/" + strconv.Itoa(projectId) + "/news.json")
+}
+
+// AllNews lists the news of every project visible to the user.
+func (c *Client) AllNews() ([]News, error) {
+	return c.fetchNews("/news.json")
+}
+
+func (c *Client) fetchNews(path string) ([]News, error) {
+	paginated := c.Limit > -1 || c.Offset > -1
+	var news []News
+	for {
+		url := c.endpoint + path + "?key=" + c.apikey + c.getPaginationClause()
+		if !paginated {
+			url += "&offset=" + strconv.Itoa(len(news))
+		}
+		r, err := c.getNewsPage(url)
+		if err != nil {
+			return nil, err
+		}
+		news = append(news, r.News...)
+		if paginated || len(r.News) == 0 || len(news) >= r.TotalCount {
+			return news, nil
+		}
+	}
+}
+
+func (c *Client) getNewsPage(url string) (*newsResult, error) {
+	res, err := c.Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +87,7 @@ func (c *Client) News(projectId int) ([]News, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r newsResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -44,5 +101,101 @@ func (c *Client) News(projectId int) ([]News, error) {
 	if err != nil {
 		return nil, err
 	}
-	return r.News, nil
+	return &r, nil
+}
+
+// NewsItem fetches a single news item with its attachments and comments.
+func (c *Client) NewsItem(id int) (*News, error) {
+	res, err := c.Get(c.endpoint + "/news/" + strconv.Itoa(id) + ".json?include=attachments,comments&key=" + c.apikey)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r newsItemResult
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.StatusCode != 200 {
+		err = errorFromResp(decoder, res.StatusCode)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r.News, nil
+}
+
+// CreateNews publishes a news item on the given project. Requires Redmine 4.1 or later.
+func (c *Client) CreateNews(projectId int, news News, userName ...string) error {
+	return c.saveNews("POST", "/projects/"+strconv.Itoa(projectId)+"/news.json", news, userName...)
+}
+
+// UpdateNews updates the news item given by the Id field of news. An empty title or
+// description is left unchanged, as Redmine requires both, while an empty summary
+// clears it. Requires Redmine 4.1 or later.
+func (c *Client) UpdateNews(news News, userName ...string) error {
+	return c.saveNews("PUT", "/news/"+strconv.Itoa(news.Id)+".json", news, userName...)
+}
+
+func (c *Client) saveNews(method string, path string, news News, userName ...string) error {
+	var nr newsRequest
+	nr.News = newsToSave{
+		Title:       news.Title,
+		Summary:     news.Summary,
+		Description: news.Description,
+		Uploads:     news.Uploads,
+	}
+	s, err := json.Marshal(nr)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, c.endpoint+path+"?key="+c.apikey, strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		err = errorFromResp(json.NewDecoder(res.Body), res.StatusCode)
+	}
+	return err
+}
+
+// DeleteNews deletes the news item irreversibly. Requires Redmine 4.1 or later.
+func (c *Client) DeleteNews(id int, userName ...string) error {
+	req, err := http.NewRequest("DELETE", c.endpoint+"/news/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		err = errorFromResp(json.NewDecoder(res.Body), res.StatusCode)
+	}
+	return err
 }
@@ -0,0 +1,431 @@
+package redmine
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// patch collects the attributes of a partial update. Only attributes that
+// have been explicitly set or cleared are sent, so the other attributes of
+// the resource are left untouched by Redmine.
+type patch struct {
+	fields       map[string]interface{}
+	customFields []*CustomField
+}
+
+// Set sets an arbitrary attribute, e.g. one added by a plugin.
+func (p *patch) Set(key string, value interface{}) {
+	if p.fields == nil {
+		p.fields = make(map[string]interface{})
+	}
+	p.fields[key] = value
+}
+
+// Clear resets an attribute. Redmine clears an attribute sent as an empty string.
+func (p *patch) Clear(key string) {
+	p.Set(key, "")
+}
+
+// IsEmpty reports whether nothing has been set.
+func (p *patch) IsEmpty() bool {
+	return len(p.fields) == 0 && len(p.customFields) == 0
+}
+
+func (p *patch) setCustomField(id int, value interface{}) {
+	setCustomField(&p.customFields, id, value)
+}
+
+func (p patch) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(p.fields)+1)
+	for k, v := range p.fields {
+		fields[k] = v
+	}
+	if len(p.customFields) > 0 {
+		fields["custom_fields"] = p.customFields
+	}
+	return json.Marshal(fields)
+}
+
+// IssuePatch is a partial issue update for PatchIssue.
+type IssuePatch struct {
+	patch
+}
+
+func NewIssuePatch() *IssuePatch {
+	return &IssuePatch{}
+}
+
+func (p *IssuePatch) SetProjectId(id int) *IssuePatch {
+	p.Set("project_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetTrackerId(id int) *IssuePatch {
+	p.Set("tracker_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetStatusId(id int) *IssuePatch {
+	p.Set("status_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetPriorityId(id int) *IssuePatch {
+	p.Set("priority_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetSubject(s string) *IssuePatch {
+	p.Set("subject", s)
+	return p
+}
+
+func (p *IssuePatch) SetDescription(s string) *IssuePatch {
+	p.Set("description", s)
+	return p
+}
+
+func (p *IssuePatch) SetCategoryId(id int) *IssuePatch {
+	p.Set("category_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetFixedVersionId(id int) *IssuePatch {
+	p.Set("fixed_version_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetAssignedToId(id int) *IssuePatch {
+	p.Set("assigned_to_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetParentIssueId(id int) *IssuePatch {
+	p.Set("parent_issue_id", id)
+	return p
+}
+
+func (p *IssuePatch) SetStartDate(d Date) *IssuePatch {
+	p.Set("start_date", d)
+	return p
+}
+
+func (p *IssuePatch) SetDueDate(d Date) *IssuePatch {
+	p.Set("due_date", d)
+	return p
+}
+
+func (p *IssuePatch) SetEstimatedHours(h float32) *IssuePatch {
+	p.Set("estimated_hours", h)
+	return p
+}
+
+func (p *IssuePatch) SetDoneRatio(r int) *IssuePatch {
+	p.Set("done_ratio", r)
+	return p
+}
+
+func (p *IssuePatch) SetIsPrivate(b bool) *IssuePatch {
+	p.Set("is_private", b)
+	return p
+}
+
+func (p *IssuePatch) SetNotes(s string) *IssuePatch {
+	p.Set("notes", s)
+	return p
+}
+
+func (p *IssuePatch) SetPrivateNotes(b bool) *IssuePatch {
+	p.Set("private_notes", b)
+	return p
+}
+
+func (p *IssuePatch) SetWatcherUserIds(ids []int) *IssuePatch {
+	p.Set("watcher_user_ids", ids)
+	return p
+}
+
+func (p *IssuePatch) SetUploads(uploads ...*Upload) *IssuePatch {
+	p.Set("uploads", uploads)
+	return p
+}
+
+func (p *IssuePatch) SetCustomField(id int, value interface{}) *IssuePatch {
+	p.setCustomField(id, value)
+	return p
+}
+
+func (p *IssuePatch) ClearCategory() *IssuePatch {
+	p.Clear("category_id")
+	return p
+}
+
+func (p *IssuePatch) ClearFixedVersion() *IssuePatch {
+	p.Clear("fixed_version_id")
+	return p
+}
+
+func (p *IssuePatch) ClearAssignedTo() *IssuePatch {
+	p.Clear("assigned_to_id")
+	return p
+}
+
+func (p *IssuePatch) ClearParentIssue() *IssuePatch {
+	p.Clear("parent_issue_id")
+	return p
+}
+
+func (p *IssuePatch) ClearStartDate() *IssuePatch {
+	p.Clear("start_date")
+	return p
+}
+
+func (p *IssuePatch) ClearDueDate() *IssuePatch {
+	p.Clear("due_date")
+	return p
+}
+
+func (p *IssuePatch) ClearEstimatedHours() *IssuePatch {
+	p.Clear("estimated_hours")
+	return p
+}
+
+func (p *IssuePatch) ClearCustomField(id int) *IssuePatch {
+	p.setCustomField(id, "")
+	return p
+}
+
+// ProjectPatch is a partial project update for PatchProject.
+type ProjectPatch struct {
+	patch
+}
+
+func NewProjectPatch() *ProjectPatch {
+	return &ProjectPatch{}
+}
+
+func (p *ProjectPatch) SetName(s string) *ProjectPatch {
+	p.Set("name", s)
+	return p
+}
+
+func (p *ProjectPatch) SetDescription(s string) *ProjectPatch {
+	p.Set("description", s)
+	return p
+}
+
+func (p *ProjectPatch) SetHomepage(s string) *ProjectPatch {
+	p.Set("homepage", s)
+	return p
+}
+
+func (p *ProjectPatch) SetIsPublic(b bool) *ProjectPatch {
+	p.Set("is_public", b)
+	return p
+}
+
+func (p *ProjectPatch) SetParentId(id int) *ProjectPatch {
+	p.Set("parent_id", id)
+	return p
+}
+
+func (p *ProjectPatch) SetInheritMembers(b bool) *ProjectPatch {
+	p.Set("inherit_members", b)
+	return p
+}
+
+func (p *ProjectPatch) SetDefaultVersionId(id int) *ProjectPatch {
+	p.Set("default_version_id", id)
+	return p
+}
+
+func (p *ProjectPatch) SetDefaultAssigneeId(id int) *ProjectPatch {
+	p.Set("default_assigned_to_id", id)
+	return p
+}
+
+func (p *ProjectPatch) SetTrackerIds(ids []int) *ProjectPatch {
+	p.Set("tracker_ids", ids)
+	return p
+}
+
+func (p *ProjectPatch) SetEnabledModuleNames(names []string) *ProjectPatch {
+	p.Set("enabled_module_names", names)
+	return p
+}
+
+func (p *ProjectPatch) SetCustomField(id int, value interface{}) *ProjectPatch {
+	p.setCustomField(id, value)
+	return p
+}
+
+func (p *ProjectPatch) ClearParent() *ProjectPatch {
+	p.Clear("parent_id")
+	return p
+}
+
+func (p *ProjectPatch) ClearDefaultVersion() *ProjectPatch {
+	p.Clear("default_version_id")
+	return p
+}
+
+func (p *ProjectPatch) ClearDefaultAssignee() *ProjectPatch {
+	p.Clear("default_assigned_to_id")
+	return p
+}
+
+// VersionPatch is a partial version update for PatchVersion.
+type VersionPatch struct {
+	patch
+}
+
+func NewVersionPatch() *VersionPatch {
+	return &VersionPatch{}
+}
+
+func (p *VersionPatch) SetName(s string) *VersionPatch {
+	p.Set("name", s)
+	return p
+}
+
+func (p *VersionPatch) SetDescription(s string) *VersionPatch {
+	p.Set("description", s)
+	return p
+}
+
+func (p *VersionPatch) SetStatus(s string) *VersionPatch {
+	p.Set("status", s)
+	return p
+}
+
+func (p *VersionPatch) SetSharing(s string) *VersionPatch {
+	p.Set("sharing", s)
+	return p
+}
+
+func (p *VersionPatch) SetDueDate(d Date) *VersionPatch {
+	p.Set("due_date", d)
+	return p
+}
+
+func (p *VersionPatch) SetWikiPageTitle(s string) *VersionPatch {
+	p.Set("wiki_page_title", s)
+	return p
+}
+
+func (p *VersionPatch) SetCustomField(id int, value interface{}) *VersionPatch {
+	p.setCustomField(id, value)
+	return p
+}
+
+func (p *VersionPatch) ClearDueDate() *VersionPatch {
+	p.Clear("due_date")
+	return p
+}
+
+// TimeEntryPatch is a partial time entry update for PatchTimeEntry.
+type TimeEntryPatch struct {
+	patch
+}
+
+func NewTimeEntryPatch() *TimeEntryPatch {
+	return &TimeEntryPatch{}
+}
+
+func (p *TimeEntryPatch) SetIssueId(id int) *TimeEntryPatch {
+	p.Set("issue_id", id)
+	return p
+}
+
+func (p *TimeEntryPatch) SetProjectId(id int) *TimeEntryPatch {
+	p.Set("project_id", id)
+	return p
+}
+
+func (p *TimeEntryPatch) SetUserId(id int) *TimeEntryPatch {
+	p.Set("user_id", id)
+	return p
+}
+
+func (p *TimeEntryPatch) SetActivityId(id int) *TimeEntryPatch {
+	p.Set("activity_id", id)
+	return p
+}
+
+func (p *TimeEntryPatch) SetSpentOn(d Date) *TimeEntryPatch {
+	p.Set("spent_on", d)
+	return p
+}
+
+func (p *TimeEntryPatch) SetHours(h float32) *TimeEntryPatch {
+	p.Set("hours", h)
+	return p
+}
+
+func (p *TimeEntryPatch) SetComments(s string) *TimeEntryPatch {
+	p.Set("comments", s)
+	return p
+}
+
+func (p *TimeEntryPatch) SetCustomField(id int, value interface{}) *TimeEntryPatch {
+	p.setCustomField(id, value)
+	return p
+}
+
+// PatchIssue sends only the attributes set on p, leaving the rest of the issue untouched.
+func (c *Client) PatchIssue(id int, p *IssuePatch, userName ...string) error {
+	return c.putIssue(id, struct {
+		Issue *IssuePatch `json:"issue"`
+	}{p}, userName...)
+}
+
+// PatchProject sends only the attributes set on p, leaving the rest of the project untouched.
+func (c *Client) PatchProject(id string, p *ProjectPatch, userName ...string) error {
+	return c.putJSON("/projects/"+id+".json", struct {
+		Project *ProjectPatch `json:"project"`
+	}{p}, userName...)
+}
+
+// PatchVersion sends only the attributes set on p, leaving the rest of the version untouched.
+func (c *Client) PatchVersion(id int, p *VersionPatch, userName ...string) error {
+	return c.putJSON("/versions/"+strconv.Itoa(id)+".json", struct {
+		Version *VersionPatch `json:"version"`
+	}{p}, userName...)
+}
+
+// PatchTimeEntry sends only the attributes set on p, leaving the rest of the time entry untouched.
+func (c *Client) PatchTimeEntry(id int, p *TimeEntryPatch, userName ...string) error {
+	return c.putJSON("/time_entries/"+strconv.Itoa(id)+".json", struct {
+		TimeEntry *TimeEntryPatch `json:"time_entry"`
+	}{p}, userName...)
+}
+
+func (c *Client) putJSON(path string, body interface{}, userName ...string) error {
+	s, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("PUT", c.endpoint+path+"?key="+c.apikey, strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		err = errorFromResp(json.NewDecoder(res.Body), res.StatusCode)
+	}
+	return err
+}
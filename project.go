@@ -13,6 +13,17 @@ type projectRequest struct {
 	Project Project `json:"project"`
 }
 
+// MarshalJSON leaves the read-only timestamps out of create and update requests.
+func (r projectRequest) MarshalJSON() ([]byte, error) {
+	project, err := marshalWithout(r.Project, "created_on", "updated_on")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Project json.RawMessage `json:"project"`
+	}{project})
+}
+
 type projectResult struct {
 	Project Project `json:"project"`
 }
@@ -21,25 +32,108 @@ type projectsResult struct {
 	Projects []Project `json:"projects"`
 }
 
+const (
+	ProjectStatusActive   int = 1
+	ProjectStatusClosed   int = 5
+	ProjectStatusArchived int = 9
+)
+
 type Project struct {
-	Id                 int               `json:"id"`
-	Parent             IdName            `json:"parent,omitempty"`
-	Name               string            `json:"name"`
-	Identifier         string            `json:"identifier"`
-	Description        string            `json:"description,omitempty"`
-	CreatedOn          string            `json:"created_on,omitempty"`
-	UpdatedOn          string            `json:"updated_on,omitempty"`
-	IsPublic           bool              `json:"is_public,omitempty"`
-	ParentID           int               `json:"parent_id,omitempty"`
-	InheritMembers     bool              `json:"inherit_members,omitempty"`
-	TrackerIDs         []int             `json:"tracker_ids,omitempty"`
-	EnabledModuleNames []string          `json:"enabled_module_names,omitempty"`
-	CustomFields       []*CustomField    `json:"custom_fields,omitempty"`
-	CustomFieldValues  map[string]string `json:"custom_field_values,omitempty"`
-}
-
-func (c *Client) Project(id string) (*Project, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + id + ".json?key=" + c.apikey)
+	Id                  int                    `json:"id"`
+	Parent              *IdName                `json:"parent,omitempty"`
+	Name                string                 `json:"name"`
+	Identifier          string                 `json:"identifier"`
+	Description         string                 `json:"description,omitempty"`
+	Homepage            string                 `json:"homepage,omitempty"`
+	Status              int                    `json:"status,omitempty"`
+	CreatedOn           Timestamp              `json:"created_on"`
+	UpdatedOn           Timestamp              `json:"updated_on"`
+	IsPublic            bool                   `json:"is_public,omitempty"`
+	ParentID            int                    `json:"parent_id,omitempty"`
+	InheritMembers      bool                   `json:"inherit_members,omitempty"`
+	DefaultVersion      *IdName                `json:"default_version,omitempty"`
+	DefaultVersionID    int                    `json:"default_version_id,omitempty"`
+	DefaultAssignee     *IdName                `json:"default_assignee,omitempty"`
+	DefaultAssigneeID   int                    `json:"default_assigned_to_id,omitempty"`
+	Trackers            []IdName               `json:"trackers,omitempty"`
+	IssueCategories     []IdName               `json:"issue_categories,omitempty"`
+	EnabledModules      []IdName               `json:"enabled_modules,omitempty"`
+	TimeEntryActivities []IdName               `json:"time_entry_activities,omitempty"`
+	IssueCustomFields   []IdName               `json:"issue_custom_fields,omitempty"`
+	TrackerIDs          []int                  `json:"tracker_ids,omitempty"`
+	EnabledModuleNames  []string               `json:"enabled_module_names,omitempty"`
+	CustomFields        []*CustomField         `json:"custom_fields,omitempty"`
+	CustomFieldValues   map[string]string      `json:"custom_field_values,omitempty"`
+	Extra               map[string]interface{} `json:"-"`
+}
+
+func (project Project) MarshalJSON() ([]byte, error) {
+	type Alias Project
+	return marshalExtra(Alias(project), project.Extra)
+}
+
+func (project *Project) UnmarshalJSON(data []byte) error {
+	type Alias Project
+	extra, err := unmarshalExtra(data, (*Alias)(project))
+	if err != nil {
+		return err
+	}
+	project.Extra = extra
+	return nil
+}
+
+// ProjectInclude names an association that Redmine only returns when explicitly requested.
+type ProjectInclude string
+
+const (
+	ProjectIncludeTrackers            ProjectInclude = "trackers"
+	ProjectIncludeIssueCategories     ProjectInclude = "issue_categories"
+	ProjectIncludeEnabledModules      ProjectInclude = "enabled_modules"
+	ProjectIncludeTimeEntryActivities ProjectInclude = "time_entry_activities"
+	ProjectIncludeIssueCustomFields   ProjectInclude = "issue_custom_fields"
+)
+
+func projectIncludeClause(include []ProjectInclude) string {
+	if len(include) == 0 {
+		return ""
+	}
+	names := make([]string, len(include))
+	for i, inc := range include {
+		names[i] = string(inc)
+	}
+	return "&include=" + strings.Join(names, ",")
+}
+
+// ProjectNode is a project together with its subprojects.
+type ProjectNode struct {
+	Project  Project
+	Children []*ProjectNode
+}
+
+// ProjectTree builds the project hierarchy from a flat list of projects.
+// Projects whose parent is not part of the list are returned as roots.
+// The input order is preserved among siblings.
+func ProjectTree(projects []Project) []*ProjectNode {
+	nodes := make(map[int]*ProjectNode, len(projects))
+	for _, p := range projects {
+		nodes[p.Id] = &ProjectNode{Project: p}
+	}
+	var roots []*ProjectNode
+	for _, p := range projects {
+		node := nodes[p.Id]
+		if p.Parent != nil && p.Parent.Id != p.Id {
+			if parent, ok := nodes[p.Parent.Id]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+func (c *Client) Project(id string, include ...ProjectInclude) (*Project, error) {
+	res, err := c.Get(c.endpoint + "/projects/" + id + ".json?key=" + c.apikey + projectIncludeClause(include))
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +156,8 @@ func (c *Client) Project(id string) (*Project, error) {
 	return &r.Project, nil
 }
 
-func (c *Client) Projects() ([]Project, error) {
-	url := fmt.Sprintf("%s/projects.json?key=%s%s", c.endpoint, c.apikey, c.getPaginationClause())
+func (c *Client) Projects(include ...ProjectInclude) ([]Project, error) {
+	url := fmt.Sprintf("%s/projects.json?key=%s%s%s", c.endpoint, c.apikey, c.getPaginationClause(), projectIncludeClause(include))
 	return c.fetchProjects(url)
 }
 
@@ -110,7 +204,7 @@ func (c *Client) CreateProject(project Project, userName ...string) (*Project, e
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -148,7 +242,7 @@ func (c *Client) UpdateProject(project Project, userName ...string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -157,7 +251,7 @@ func (c *Client) UpdateProject(project Project, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 	if res.StatusCode != 204 {
 		decoder := json.NewDecoder(res.Body)
@@ -180,7 +274,7 @@ func (c *Client) DeleteProject(id string, userName ...string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -189,7 +283,7 @@ func (c *Client) DeleteProject(id string, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
@@ -202,3 +296,47 @@ func (c *Client) DeleteProject(id string, userName ...string) error {
 	}
 	return err
 }
+
+// ArchiveProject archives the given project. Archived projects are hidden and read-only.
+func (c *Client) ArchiveProject(id string, userName ...string) error {
+	return c.changeProjectStatus(id, "archive", userName...)
+}
+
+// UnarchiveProject restores an archived project.
+func (c *Client) UnarchiveProject(id string, userName ...string) error {
+	return c.changeProjectStatus(id, "unarchive", userName...)
+}
+
+// CloseProject closes the given project, keeping it visible but read-only.
+func (c *Client) CloseProject(id string, userName ...string) error {
+	return c.changeProjectStatus(id, "close", userName...)
+}
+
+// ReopenProject reopens a closed project.
+func (c *Client) ReopenProject(id string, userName ...string) error {
+	return c.changeProjectStatus(id, "reopen", userName...)
+}
+
+func (c *Client) changeProjectStatus(id string, action string, userName ...string) error {
+	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+id+"/"+action+".json?key="+c.apikey, strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		err = errorFromResp(json.NewDecoder(res.Body), res.StatusCode)
+	}
+	return err
+}
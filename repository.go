@@ -0,0 +1,76 @@
+package redmine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Changeset is a SCM revision associated with an issue, as listed with include=changesets.
+type Changeset struct {
+	Revision    string    `json:"revision"`
+	User        *IdName   `json:"user,omitempty"`
+	Comments    string    `json:"comments"`
+	CommittedOn Timestamp `json:"committed_on"`
+}
+
+// IssueChangesets fetches the changesets associated with the issue.
+func (c *Client) IssueChangesets(issueId int) ([]Changeset, error) {
+	issue, err := getOneIssue(c, issueId, map[string]string{"include": "changesets"})
+	if err != nil {
+		return nil, err
+	}
+	return issue.Changesets, nil
+}
+
+// AddRelatedIssueToRevision links the issue to a revision of a project repository.
+// An empty repositoryId designates the default repository of the project.
+func (c *Client) AddRelatedIssueToRevision(projectId string, repositoryId string, revision string, issueId int, userName ...string) error {
+	s, err := json.Marshal(struct {
+		IssueId int `json:"issue_id"`
+	}{issueId})
+	if err != nil {
+		return err
+	}
+	return c.revisionIssuesRequest("POST", revisionIssuesPath(projectId, repositoryId, revision)+".json", string(s), userName...)
+}
+
+// RemoveRelatedIssueFromRevision removes the link between the issue and a revision of a project repository.
+// An empty repositoryId designates the default repository of the project.
+func (c *Client) RemoveRelatedIssueFromRevision(projectId string, repositoryId string, revision string, issueId int, userName ...string) error {
+	return c.revisionIssuesRequest("DELETE", revisionIssuesPath(projectId, repositoryId, revision)+"/"+strconv.Itoa(issueId)+".json", "", userName...)
+}
+
+func revisionIssuesPath(projectId string, repositoryId string, revision string) string {
+	path := "/projects/" + projectId + "/repository"
+	if repositoryId != "" {
+		path += "/" + url.PathEscape(repositoryId)
+	}
+	return path + "/revisions/" + url.PathEscape(revision) + "/issues"
+}
+
+func (c *Client) revisionIssuesRequest(method string, path string, body string, userName ...string) error {
+	req, err := http.NewRequest(method, c.endpoint+path+"?key="+c.apikey, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		err = errorFromResp(json.NewDecoder(res.Body), res.StatusCode)
+	}
+	return err
+}
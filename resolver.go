@@ -0,0 +1,335 @@
+package redmine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UnknownNameError is returned by Resolver when no object has the given name.
+type UnknownNameError struct {
+	Kind string
+	Name string
+}
+
+func (e *UnknownNameError) Error() string {
+	return fmt.Sprintf("unknown %s %q", e.Kind, e.Name)
+}
+
+// AmbiguousNameError is returned by Resolver when several objects share the given name.
+type AmbiguousNameError struct {
+	Kind string
+	Name string
+	Ids  []int
+}
+
+func (e *AmbiguousNameError) Error() string {
+	ids := make([]string, len(e.Ids))
+	for i, id := range e.Ids {
+		ids[i] = strconv.Itoa(id)
+	}
+	return fmt.Sprintf("ambiguous %s %q matches ids %s", e.Kind, e.Name, strings.Join(ids, ", "))
+}
+
+// UnknownIdError is returned by Resolver when no object has the given id.
+type UnknownIdError struct {
+	Kind string
+	Id   int
+}
+
+func (e *UnknownIdError) Error() string {
+	return fmt.Sprintf("unknown %s id %d", e.Kind, e.Id)
+}
+
+// resolverPageSize is the page size used to load paginated collections such as projects and users.
+const resolverPageSize = 100
+
+type namedObject struct {
+	id    int
+	names []string // display name first, then alternative keys such as login or identifier
+}
+
+type resolverEntry struct {
+	mu      sync.Mutex // held while the objects are loaded, so that each key is loaded once
+	loaded  time.Time
+	objects []namedObject
+}
+
+// Resolver translates human readable names into the numeric ids required by
+// write requests, and back. Reference data is fetched lazily on first use and
+// cached for the configured TTL.
+type Resolver struct {
+	client *Client
+	ttl    time.Duration
+
+	mu    sync.Mutex // guards the cache map only, loads lock their entry
+	cache map[string]*resolverEntry
+}
+
+// NewResolver returns a resolver backed by c. A ttl of zero or less keeps the
+// cached data until Invalidate is called.
+func NewResolver(c *Client, ttl time.Duration) *Resolver {
+	return &Resolver{client: c, ttl: ttl, cache: make(map[string]*resolverEntry)}
+}
+
+// Invalidate drops all cached data.
+func (r *Resolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = make(map[string]*resolverEntry)
+}
+
+func (r *Resolver) objects(key string, load func() ([]namedObject, error)) ([]namedObject, error) {
+	r.mu.Lock()
+	e, ok := r.cache[key]
+	if !ok {
+		e = &resolverEntry{}
+		r.cache[key] = e
+	}
+	r.mu.Unlock()
+
+	// concurrent lookups of the same key wait for a single load, other keys are not blocked
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.loaded.IsZero() && (r.ttl <= 0 || time.Now().Sub(e.loaded) < r.ttl) {
+		return e.objects, nil
+	}
+	objects, err := load()
+	if err != nil {
+		return nil, err
+	}
+	e.loaded, e.objects = time.Now(), objects
+	return objects, nil
+}
+
+func (r *Resolver) resolveId(kind string, key string, name string, load func() ([]namedObject, error)) (int, error) {
+	objects, err := r.objects(key, load)
+	if err != nil {
+		return 0, err
+	}
+	// exact matches win over case-insensitive ones
+	for _, equal := range []func(a, b string) bool{
+		func(a, b string) bool { return a == b },
+		strings.EqualFold,
+	} {
+		var ids []int
+		for _, o := range objects {
+			for _, n := range o.names {
+				if equal(n, name) {
+					ids = append(ids, o.id)
+					break
+				}
+			}
+		}
+		if len(ids) == 1 {
+			return ids[0], nil
+		}
+		if len(ids) > 1 {
+			sort.Ints(ids)
+			return 0, &AmbiguousNameError{Kind: kind, Name: name, Ids: ids}
+		}
+	}
+	return 0, &UnknownNameError{Kind: kind, Name: name}
+}
+
+func (r *Resolver) resolveName(kind string, key string, id int, load func() ([]namedObject, error)) (string, error) {
+	objects, err := r.objects(key, load)
+	if err != nil {
+		return "", err
+	}
+	for _, o := range objects {
+		if o.id == id {
+			return o.names[0], nil
+		}
+	}
+	return "", &UnknownIdError{Kind: kind, Id: id}
+}
+
+func (r *Resolver) loadTrackers() ([]namedObject, error) {
+	trackers, err := r.client.Trackers()
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]namedObject, len(trackers))
+	for i, t := range trackers {
+		objects[i] = namedObject{t.Id, []string{t.Name}}
+	}
+	return objects, nil
+}
+
+func (r *Resolver) loadStatuses() ([]namedObject, error) {
+	statuses, err := r.client.IssueStatuses()
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]namedObject, len(statuses))
+	for i, s := range statuses {
+		objects[i] = namedObject{s.Id, []string{s.Name}}
+	}
+	return objects, nil
+}
+
+func (r *Resolver) loadPriorities() ([]namedObject, error) {
+	priorities, err := r.client.IssuePriorities()
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]namedObject, len(priorities))
+	for i, p := range priorities {
+		objects[i] = namedObject{p.Id, []string{p.Name}}
+	}
+	return objects, nil
+}
+
+func (r *Resolver) loadActivities() ([]namedObject, error) {
+	activities, err := r.client.TimeEntryActivities()
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]namedObject, len(activities))
+	for i, a := range activities {
+		objects[i] = namedObject{a.Id, []string{a.Name}}
+	}
+	return objects, nil
+}
+
+// pagedClient returns a copy of the client fetching the page at the given offset.
+func (r *Resolver) pagedClient(offset int) *Client {
+	c := *r.client
+	c.Limit = resolverPageSize
+	c.Offset = offset
+	return &c
+}
+
+func (r *Resolver) loadProjects() ([]namedObject, error) {
+	var objects []namedObject
+	for {
+		projects, err := r.pagedClient(len(objects)).Projects()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range projects {
+			objects = append(objects, namedObject{p.Id, []string{p.Name, p.Identifier}})
+		}
+		if len(projects) < resolverPageSize {
+			return objects, nil
+		}
+	}
+}
+
+func (r *Resolver) loadUsers() ([]namedObject, error) {
+	var objects []namedObject
+	for {
+		users, err := r.pagedClient(len(objects)).Users()
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			name := strings.TrimSpace(u.Firstname + " " + u.Lastname)
+			objects = append(objects, namedObject{u.Id, []string{name, u.Login}})
+		}
+		if len(users) < resolverPageSize {
+			return objects, nil
+		}
+	}
+}
+
+func (r *Resolver) versionsLoader(projectId int) func() ([]namedObject, error) {
+	return func() ([]namedObject, error) {
+		versions, err := r.client.Versions(projectId)
+		if err != nil {
+			return nil, err
+		}
+		objects := make([]namedObject, len(versions))
+		for i, v := range versions {
+			objects[i] = namedObject{v.Id, []string{v.Name}}
+		}
+		return objects, nil
+	}
+}
+
+func (r *Resolver) categoriesLoader(projectId int) func() ([]namedObject, error) {
+	return func() ([]namedObject, error) {
+		categories, err := r.client.IssueCategories(projectId)
+		if err != nil {
+			return nil, err
+		}
+		objects := make([]namedObject, len(categories))
+		for i, c := range categories {
+			objects[i] = namedObject{c.Id, []string{c.Name}}
+		}
+		return objects, nil
+	}
+}
+
+func (r *Resolver) TrackerId(name string) (int, error) {
+	return r.resolveId("tracker", "trackers", name, r.loadTrackers)
+}
+
+func (r *Resolver) TrackerName(id int) (string, error) {
+	return r.resolveName("tracker", "trackers", id, r.loadTrackers)
+}
+
+func (r *Resolver) StatusId(name string) (int, error) {
+	return r.resolveId("issue status", "issue_statuses", name, r.loadStatuses)
+}
+
+func (r *Resolver) StatusName(id int) (string, error) {
+	return r.resolveName("issue status", "issue_statuses", id, r.loadStatuses)
+}
+
+func (r *Resolver) PriorityId(name string) (int, error) {
+	return r.resolveId("issue priority", "issue_priorities", name, r.loadPriorities)
+}
+
+func (r *Resolver) PriorityName(id int) (string, error) {
+	return r.resolveName("issue priority", "issue_priorities", id, r.loadPriorities)
+}
+
+func (r *Resolver) ActivityId(name string) (int, error) {
+	return r.resolveId("time entry activity", "time_entry_activities", name, r.loadActivities)
+}
+
+func (r *Resolver) ActivityName(id int) (string, error) {
+	return r.resolveName("time entry activity", "time_entry_activities", id, r.loadActivities)
+}
+
+// ProjectId resolves a project name or identifier.
+func (r *Resolver) ProjectId(nameOrIdentifier string) (int, error) {
+	return r.resolveId("project", "projects", nameOrIdentifier, r.loadProjects)
+}
+
+func (r *Resolver) ProjectName(id int) (string, error) {
+	return r.resolveName("project", "projects", id, r.loadProjects)
+}
+
+// UserId resolves a user login or full name ("Firstname Lastname"). Listing users requires admin privileges.
+func (r *Resolver) UserId(loginOrName string) (int, error) {
+	return r.resolveId("user", "users", loginOrName, r.loadUsers)
+}
+
+// UserName returns the full name of the user.
+func (r *Resolver) UserName(id int) (string, error) {
+	return r.resolveName("user", "users", id, r.loadUsers)
+}
+
+// VersionId resolves the name of a version available to the given project.
+func (r *Resolver) VersionId(projectId int, name string) (int, error) {
+	return r.resolveId("version", "versions:"+strconv.Itoa(projectId), name, r.versionsLoader(projectId))
+}
+
+func (r *Resolver) VersionName(projectId int, id int) (string, error) {
+	return r.resolveName("version", "versions:"+strconv.Itoa(projectId), id, r.versionsLoader(projectId))
+}
+
+// CategoryId resolves the name of an issue category of the given project.
+func (r *Resolver) CategoryId(projectId int, name string) (int, error) {
+	return r.resolveId("issue category", "issue_categories:"+strconv.Itoa(projectId), name, r.categoriesLoader(projectId))
+}
+
+func (r *Resolver) CategoryName(projectId int, id int) (string, error) {
+	return r.resolveName("issue category", "issue_categories:"+strconv.Itoa(projectId), id, r.categoriesLoader(projectId))
+}
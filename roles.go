@@ -3,6 +3,7 @@ package redmine
 import (
 	"encoding/json"
 	"errors"
+	"strconv"
 	"strings"
 )
 
@@ -10,6 +11,30 @@ type rolesResult struct {
 	Roles []IdName `json:"roles"`
 }
 
+type roleResult struct {
+	Role Role `json:"role"`
+}
+
+type Role struct {
+	Id                    int      `json:"id"`
+	Name                  string   `json:"name"`
+	Assignable            bool     `json:"assignable"`
+	IssuesVisibility      string   `json:"issues_visibility"`
+	TimeEntriesVisibility string   `json:"time_entries_visibility"`
+	UsersVisibility       string   `json:"users_visibility"`
+	Permissions           []string `json:"permissions"`
+}
+
+// HasPermission reports whether the role grants the given permission, e.g. "edit_issues".
+func (r *Role) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) Roles() ([]IdName, error) {
 	res, err := c.Get(c.endpoint + "/roles.json?key=" + c.apikey + c.getPaginationClause())
 	if err != nil {
@@ -33,3 +58,27 @@ func (c *Client) Roles() ([]IdName, error) {
 	}
 	return r.Roles, nil
 }
+
+// Role fetches the role with its visibility settings and permissions.
+func (c *Client) Role(id int) (*Role, error) {
+	res, err := c.Get(c.endpoint + "/roles/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r roleResult
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.StatusCode != 200 {
+		err = errorFromResp(decoder, res.StatusCode)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r.Role, nil
+}
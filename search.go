@@ -0,0 +1,138 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Search scopes accepted by Redmine.
+const (
+	SearchScopeAll         string = "all"
+	SearchScopeMyProjects  string = "my_projects"
+	SearchScopeSubprojects string = "subprojects"
+)
+
+// SearchOptions describes a query against /search.json.
+// When none of the resource type toggles is set, Redmine searches every type.
+// Limit and Offset fall back to the client's pagination settings when negative or zero,
+// so an Offset of 0 cannot override a non-zero client Offset: reset the client's
+// Offset to -1 to search from the first result.
+type SearchOptions struct {
+	Query      string
+	ProjectId  string // optional, restricts the search to the given project
+	Scope      string
+	AllWords   bool
+	TitlesOnly bool
+	OpenIssues bool
+
+	Issues     bool
+	News       bool
+	Documents  bool
+	Changesets bool
+	WikiPages  bool
+	Messages   bool
+	Projects   bool
+
+	Limit  int
+	Offset int
+}
+
+type SearchResult struct {
+	Id          int       `json:"id"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Datetime    Timestamp `json:"datetime"`
+}
+
+// SearchPage holds one page of search results together with paging information.
+type SearchPage struct {
+	Results    []SearchResult `json:"results"`
+	TotalCount int            `json:"total_count"`
+	Offset     int            `json:"offset"`
+	Limit      int            `json:"limit"`
+}
+
+// Search runs a full-text search and returns a single page of results.
+func (c *Client) Search(ctx context.Context, opts SearchOptions) (*SearchPage, error) {
+	if opts.Query == "" {
+		return nil, errors.New("search query is empty")
+	}
+	path := "/search.json"
+	if opts.ProjectId != "" {
+		path = "/projects/" + url.PathEscape(opts.ProjectId) + "/search.json"
+	}
+	params := searchParams(opts)
+	params["key"] = c.apikey
+	if opts.Limit <= 0 && c.Limit > -1 {
+		params["limit"] = strconv.Itoa(c.Limit)
+	}
+	if opts.Offset <= 0 && c.Offset > -1 {
+		params["offset"] = strconv.Itoa(c.Offset)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint+path+"?"+mapToQueryString(params), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+
+	decoder := json.NewDecoder(res.Body)
+	var r SearchPage
+	if res.StatusCode != 200 {
+		err = errorFromResp(decoder, res.StatusCode)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func searchParams(opts SearchOptions) map[string]string {
+	params := map[string]string{"q": opts.Query}
+	if opts.Scope != "" {
+		params["scope"] = opts.Scope
+	}
+	flags := []struct {
+		name string
+		set  bool
+	}{
+		{"all_words", opts.AllWords},
+		{"titles_only", opts.TitlesOnly},
+		{"open_issues", opts.OpenIssues},
+		{"issues", opts.Issues},
+		{"news", opts.News},
+		{"documents", opts.Documents},
+		{"changesets", opts.Changesets},
+		{"wiki_pages", opts.WikiPages},
+		{"messages", opts.Messages},
+		{"projects", opts.Projects},
+	}
+	for _, f := range flags {
+		if f.set {
+			params[f.name] = "1"
+		}
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		params["offset"] = strconv.Itoa(opts.Offset)
+	}
+	return params
+}
@@ -0,0 +1,187 @@
+package redmine_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkServer struct {
+	mu       sync.Mutex
+	inFlight int
+	maxSeen  int
+	puts     []string
+	gets     []string
+	nextId   int
+}
+
+func (s *bulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.maxSeen {
+		s.maxSeen = s.inFlight
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	switch {
+	case r.Method == "PUT" && r.URL.Path == "/issues/3.json":
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors":["Status is invalid"]}`))
+	case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/issues/"):
+		s.mu.Lock()
+		s.puts = append(s.puts, r.URL.Path)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/issues/"):
+		s.mu.Lock()
+		s.gets = append(s.gets, r.URL.Path)
+		s.mu.Unlock()
+		w.Write([]byte(`{"issue":{"id":1,"subject":"x"}}`))
+	case r.Method == "POST" && r.URL.Path == "/issues.json":
+		var req struct {
+			Issue map[string]interface{} `json:"issue"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		s.mu.Lock()
+		s.nextId++
+		id := s.nextId
+		s.mu.Unlock()
+		resp, _ := json.Marshal(map[string]interface{}{"issue": map[string]interface{}{"id": id, "subject": req.Issue["subject"]}})
+		w.WriteHeader(http.StatusCreated)
+		w.Write(resp)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func Test_BulkUpdateIssuesContinuesOnError(t *testing.T) {
+	s := &bulkServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	client := lkredmine.NewClient(server.URL, "apikey")
+
+	ids := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	results, err := client.BulkUpdateIssues(context.Background(), ids, lkredmine.NewIssuePatch().SetNotes("retagged"), lkredmine.BulkOptions{Concurrency: 3})
+
+	var bulkErr *lkredmine.BulkError
+	assert.True(t, errors.As(err, &bulkErr))
+	assert.Equal(t, 1, bulkErr.Failed)
+	assert.Equal(t, 0, bulkErr.Skipped)
+	assert.Equal(t, len(ids), len(results))
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+		assert.Equal(t, ids[i], r.Id)
+	}
+	assert.EqualError(t, results[2].Err, "Status is invalid")
+	assert.Equal(t, 7, len(s.puts))
+	assert.True(t, s.maxSeen <= 3)
+}
+
+func Test_BulkUpdateIssuesStopsOnError(t *testing.T) {
+	s := &bulkServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	client := lkredmine.NewClient(server.URL, "apikey")
+
+	results, err := client.BulkUpdateIssues(context.Background(), []int{1, 3, 4, 5}, lkredmine.NewIssuePatch().SetNotes("x"), lkredmine.BulkOptions{Concurrency: 1, StopOnError: true})
+	var bulkErr *lkredmine.BulkError
+	assert.True(t, errors.As(err, &bulkErr))
+	assert.Equal(t, 1, bulkErr.Failed)
+	assert.Equal(t, 2, bulkErr.Skipped)
+	assert.Nil(t, results[0].Err)
+	assert.True(t, results[2].Skipped)
+	assert.Equal(t, []string{"/issues/1.json"}, s.puts)
+}
+
+func Test_BulkDryRun(t *testing.T) {
+	s := &bulkServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	client := lkredmine.NewClient(server.URL, "apikey")
+
+	results, err := client.BulkUpdateIssues(context.Background(), []int{1, 2}, lkredmine.NewIssuePatch().SetNotes("x"), lkredmine.BulkOptions{DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.Empty(t, s.puts)
+	assert.Equal(t, 2, len(s.gets))
+
+	results, err = client.BulkCreateIssues(context.Background(), []lkredmine.IssueToCreate{
+		{ProjectId: 1, Subject: "ok"},
+		{ProjectId: 1},
+	}, lkredmine.BulkOptions{DryRun: true})
+	assert.NotNil(t, err)
+	assert.Nil(t, results[0].Err)
+	assert.EqualError(t, results[1].Err, "subject is required")
+	assert.Equal(t, 0, s.nextId)
+
+	_, err = client.BulkUpdateIssues(context.Background(), []int{1}, lkredmine.NewIssuePatch(), lkredmine.BulkOptions{})
+	assert.NotNil(t, err)
+}
+
+func Test_BulkCreateIssues(t *testing.T) {
+	s := &bulkServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	client := lkredmine.NewClient(server.URL, "apikey")
+
+	results, err := client.BulkCreateIssues(context.Background(), []lkredmine.IssueToCreate{
+		{ProjectId: 1, Subject: "a"},
+		{ProjectId: 1, Subject: "b"},
+		{ProjectId: 1, Subject: "c"},
+	}, lkredmine.BulkOptions{Concurrency: 2})
+	assert.Nil(t, err)
+	subjects := make(map[string]bool)
+	ids := make(map[int]bool)
+	for _, r := range results {
+		assert.Nil(t, r.Err)
+		assert.Equal(t, r.Issue.Id, r.Id)
+		subjects[r.Issue.Subject] = true
+		ids[r.Id] = true
+	}
+	assert.Equal(t, map[string]bool{"a": true, "b": true, "c": true}, subjects)
+	assert.Equal(t, 3, len(ids))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = client.BulkCreateIssues(ctx, []lkredmine.IssueToCreate{{ProjectId: 1, Subject: "d"}}, lkredmine.BulkOptions{})
+	assert.NotNil(t, err)
+	assert.True(t, results[0].Skipped)
+	assert.Equal(t, context.Canceled, results[0].Err)
+}
+
+func Test_BulkDryRunHonorsCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.Write([]byte(`{"issue":{"id":1}}`))
+	}))
+	defer server.Close()
+	client := lkredmine.NewClient(server.URL, "apikey")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	results, err := client.BulkUpdateIssues(ctx, []int{1, 2}, lkredmine.NewIssuePatch().SetNotes("x"), lkredmine.BulkOptions{DryRun: true})
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+	for _, r := range results {
+		assert.True(t, errors.Is(r.Err, context.DeadlineExceeded))
+	}
+}
@@ -0,0 +1,217 @@
+package redmine_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CacheRevalidatesWithETag(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	client.EnableCache(lkredmine.CacheOptions{TTLs: map[string]time.Duration{"trackers": time.Hour}})
+
+	for i := 0; i < 3; i++ {
+		trackers, err := client.Trackers()
+		assert.Nil(t, err)
+		assert.Equal(t, "Bug", trackers[0].Name)
+	}
+	assert.Equal(t, 1, requests)
+
+	client.InvalidateCache("trackers")
+	trackers, err := client.Trackers()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(trackers))
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 0, notModified)
+
+	// with no TTL the response is revalidated on every call
+	client.EnableCache(lkredmine.CacheOptions{})
+	client.Trackers()
+	trackers, err = client.Trackers()
+	assert.Nil(t, err)
+	assert.Equal(t, "Bug", trackers[0].Name)
+	assert.Equal(t, 4, requests)
+	assert.Equal(t, 1, notModified)
+}
+
+func Test_CacheInvalidatedByWrites(t *testing.T) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/projects/1/versions.json":
+			gets++
+			w.Write([]byte(`{"versions":[{"id":3,"name":"1.0"}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/versions/3.json":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	client.EnableCache(lkredmine.CacheOptions{DefaultTTL: time.Hour})
+
+	client.Versions(1)
+	client.Versions(1)
+	assert.Equal(t, 1, gets)
+
+	assert.Nil(t, client.UpdateVersion(lkredmine.Version{Id: 3, Name: "1.1"}))
+	client.Versions(1)
+	assert.Equal(t, 2, gets)
+}
+
+func Test_CacheHonorsNoStore(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"}]}`))
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	client.EnableCache(lkredmine.CacheOptions{DefaultTTL: time.Hour})
+	client.IssueStatuses()
+	client.IssueStatuses()
+	assert.Equal(t, 2, requests)
+}
+
+func Test_CacheTTLForEnumerations(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"issue_priorities":[{"id":1,"name":"Normal"}],"time_entry_activities":[{"id":9,"name":"Dev"}]}`))
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	client.EnableCache(lkredmine.CacheOptions{DefaultTTL: -1, TTLs: map[string]time.Duration{"issue_priorities": time.Hour}})
+
+	client.IssuePriorities()
+	priorities, err := client.IssuePriorities()
+	assert.Nil(t, err)
+	assert.Equal(t, "Normal", priorities[0].Name)
+	assert.Equal(t, 1, requests)
+
+	// other enumerations fall back to DefaultTTL, which disables caching here
+	client.TimeEntryActivities()
+	client.TimeEntryActivities()
+	assert.Equal(t, 3, requests)
+
+	client.InvalidateCache("issue_priorities")
+	client.IssuePriorities()
+	assert.Equal(t, 4, requests)
+}
+
+func Test_CacheSharedBackendInvalidation(t *testing.T) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			gets++
+			w.Write([]byte(`{"versions":[{"id":3,"name":"1.0"}],"trackers":[{"id":1,"name":"Bug"}]}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	// two clients standing for two processes sharing one backend
+	backend := lkredmine.NewMemoryCache()
+	reader := lkredmine.NewClient(server.URL, "apikey")
+	reader.EnableCache(lkredmine.CacheOptions{Backend: backend, DefaultTTL: time.Hour})
+	writer := lkredmine.NewClient(server.URL, "apikey")
+	writer.EnableCache(lkredmine.CacheOptions{Backend: backend, DefaultTTL: time.Hour})
+
+	reader.Versions(1)
+	reader.Versions(1)
+	assert.Equal(t, 1, gets)
+
+	assert.Nil(t, writer.UpdateVersion(lkredmine.Version{Id: 3, Name: "1.1"}))
+	reader.Versions(1)
+	assert.Equal(t, 2, gets)
+
+	reader.Trackers()
+	writer.InvalidateCache()
+	reader.Trackers()
+	reader.Versions(1)
+	assert.Equal(t, 5, gets)
+}
+
+func Test_MemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	fresh := time.Now().Add(time.Hour)
+	cache := lkredmine.NewMemoryCacheSize(2)
+	cache.Set("a", &lkredmine.CachedResponse{Expires: fresh})
+	cache.Set("b", &lkredmine.CachedResponse{Expires: fresh})
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+	cache.Set("c", &lkredmine.CachedResponse{Expires: fresh})
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+
+	// expired responses are only kept when they can be revalidated
+	expired := time.Now().Add(-time.Minute)
+	cache.Set("stale", &lkredmine.CachedResponse{Expires: expired})
+	cache.Set("etag", &lkredmine.CachedResponse{Expires: expired, ETag: `"v1"`})
+	_, ok = cache.Get("stale")
+	assert.False(t, ok)
+	_, ok = cache.Get("etag")
+	assert.True(t, ok)
+	assert.Equal(t, 1, cache.Len())
+}
+
+type keyRecordingCache struct {
+	*lkredmine.MemoryCache
+	keys []string
+}
+
+func (c *keyRecordingCache) Set(key string, resp *lkredmine.CachedResponse) {
+	c.keys = append(c.keys, key)
+	c.MemoryCache.Set(key, resp)
+}
+
+func Test_CacheKeyHidesAPIKey(t *testing.T) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	}))
+	defer server.Close()
+
+	backend := &keyRecordingCache{MemoryCache: lkredmine.NewMemoryCache()}
+	alice := lkredmine.NewClient(server.URL, "alice-secret")
+	alice.EnableCache(lkredmine.CacheOptions{Backend: backend, DefaultTTL: time.Hour})
+	bob := lkredmine.NewClient(server.URL, "bob-secret")
+	bob.EnableCache(lkredmine.CacheOptions{Backend: backend, DefaultTTL: time.Hour})
+
+	alice.Trackers()
+	alice.Trackers()
+	bob.Trackers()
+	assert.Equal(t, 2, gets)
+	assert.Len(t, backend.keys, 2)
+	for _, key := range backend.keys {
+		assert.NotContains(t, key, "secret")
+		assert.NotContains(t, key, "key=")
+	}
+}
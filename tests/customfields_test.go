@@ -0,0 +1,112 @@
+package redmine_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnmarshalCustomFieldDefinition(t *testing.T) {
+	var def lkredmine.CustomFieldDefinition
+	err := json.Unmarshal([]byte(`{
+		"id": 1,
+		"name": "Severity",
+		"customized_type": "issue",
+		"field_format": "list",
+		"regexp": "",
+		"min_length": null,
+		"max_length": null,
+		"is_required": true,
+		"is_filter": true,
+		"searchable": false,
+		"multiple": false,
+		"default_value": null,
+		"visible": true,
+		"possible_values": [{"value": "Minor", "label": "Minor"}, {"value": "Critical", "label": "Critical"}],
+		"trackers": [{"id": 1, "name": "Bug"}],
+		"roles": []
+	}`), &def)
+	assert.Nil(t, err)
+	assert.Equal(t, "issue", def.CustomizedType)
+	assert.Equal(t, "list", def.FieldFormat)
+	assert.True(t, def.IsRequired)
+	assert.Len(t, def.PossibleValues, 2)
+	assert.True(t, def.EnabledForTracker(1))
+	assert.False(t, def.EnabledForTracker(2))
+
+	assert.Nil(t, def.Validate("Critical"))
+	assert.NotNil(t, def.Validate("Blocker"))
+	assert.NotNil(t, def.Validate(""))
+	assert.NotNil(t, def.Validate([]string{"Minor", "Critical"}))
+}
+
+func Test_ValidateCustomFieldFormats(t *testing.T) {
+	intField := lkredmine.CustomFieldDefinition{Name: "Count", FieldFormat: "int"}
+	assert.Nil(t, intField.Validate("12"))
+	assert.Nil(t, intField.Validate(12))
+	assert.NotNil(t, intField.Validate("twelve"))
+
+	dateField := lkredmine.CustomFieldDefinition{Name: "Deadline", FieldFormat: "date"}
+	assert.Nil(t, dateField.Validate("2024-07-20"))
+	assert.NotNil(t, dateField.Validate("20/07/2024"))
+
+	stringField := lkredmine.CustomFieldDefinition{Name: "Code", FieldFormat: "string", MinLength: 2, MaxLength: 4, Regexp: "^[A-Z]+$"}
+	assert.Nil(t, stringField.Validate("ABC"))
+	assert.NotNil(t, stringField.Validate("A"))
+	assert.NotNil(t, stringField.Validate("ABCDE"))
+	assert.NotNil(t, stringField.Validate("abc"))
+	assert.Nil(t, stringField.Validate(nil))
+
+	// Ruby-only syntax such as possessive quantifiers is left for Redmine to check
+	rubyField := lkredmine.CustomFieldDefinition{Name: "Ticket", FieldFormat: "string", Regexp: `\A[A-Z]++-\d+\z`, MaxLength: 10}
+	assert.Nil(t, rubyField.Validate("OPS-12"))
+	assert.NotNil(t, rubyField.Validate("OPS-1234567"))
+	anchoredField := lkredmine.CustomFieldDefinition{Name: "Ticket", FieldFormat: "string", Regexp: `\A[A-Z]+-\d+\z`}
+	assert.Nil(t, anchoredField.Validate("OPS-12"))
+	assert.NotNil(t, anchoredField.Validate("ops"))
+}
+
+func Test_CustomFieldTypedValues(t *testing.T) {
+	var issue lkredmine.Issue
+	err := json.Unmarshal([]byte(`{
+		"id": 1,
+		"custom_fields": [
+			{"id": 1, "name": "Severity", "value": "Critical"},
+			{"id": 2, "name": "Reviewers", "multiple": true, "value": ["5", "7"]},
+			{"id": 3, "name": "Deadline", "value": "2024-07-20"},
+			{"id": 4, "name": "Billable", "value": "1"},
+			{"id": 5, "name": "Budget", "value": ""}
+		]
+	}`), &issue)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "Critical", issue.CustomFieldByName("Severity").StringValue())
+	assert.Nil(t, issue.CustomFieldByName("Missing"))
+
+	reviewers := issue.CustomFieldById(2)
+	assert.Equal(t, []string{"5", "7"}, reviewers.Values())
+	ids, err := reviewers.IntValues()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{5, 7}, ids)
+	_, err = reviewers.IntValue()
+	assert.NotNil(t, err)
+
+	deadline, err := issue.CustomFieldByName("Deadline").DateValue()
+	assert.Nil(t, err)
+	assert.Equal(t, 20, deadline.Day())
+
+	billable, err := issue.CustomFieldByName("Billable").BoolValue()
+	assert.Nil(t, err)
+	assert.True(t, billable)
+
+	_, err = issue.CustomFieldByName("Budget").IntValue()
+	assert.Equal(t, lkredmine.ErrEmptyCustomField, err)
+
+	issue.SetCustomField(1, "Minor")
+	issue.SetCustomField(9, "new")
+	assert.Equal(t, "Minor", issue.CustomFieldById(1).StringValue())
+	assert.Equal(t, "new", issue.CustomFieldById(9).StringValue())
+	assert.Len(t, issue.CustomFields, 6)
+}
@@ -0,0 +1,59 @@
+package redmine_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DocumentCategories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/enumerations/document_categories.json", r.URL.Path)
+		w.Write([]byte(`{"document_categories":[
+			{"id":1,"name":"User documentation","is_default":true,"active":true},
+			{"id":2,"name":"Technical documentation","is_default":false,"active":false,
+			 "custom_fields":[{"id":3,"name":"Owner","value":"docs"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	categories, err := client.DocumentCategories()
+	assert.Nil(t, err)
+	assert.Len(t, categories, 2)
+	assert.True(t, categories[0].IsDefault)
+	assert.True(t, categories[0].Active)
+	assert.False(t, categories[1].Active)
+	assert.Equal(t, "docs", categories[1].CustomFieldByName("Owner").StringValue())
+}
+
+func Test_PrioritiesAndActivitiesUseEnumerations(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		switch r.URL.Path {
+		case "/enumerations/issue_priorities.json":
+			w.Write([]byte(`{"issue_priorities":[{"id":1,"name":"Low","is_default":false,"active":true},{"id":2,"name":"Normal","is_default":true,"active":true}]}`))
+		case "/enumerations/time_entry_activities.json":
+			w.Write([]byte(`{"time_entry_activities":[{"id":9,"name":"Design","is_default":false,"active":false}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	priorities, err := client.IssuePriorities()
+	assert.Nil(t, err)
+	assert.Equal(t, []lkredmine.IssuePriority{{Id: 1, Name: "Low", Active: true}, {Id: 2, Name: "Normal", IsDefault: true, Active: true}}, priorities)
+
+	activities, err := client.TimeEntryActivities()
+	assert.Nil(t, err)
+	assert.Equal(t, []lkredmine.TimeEntryActivity{{Id: 9, Name: "Design"}}, activities)
+
+	_, err = client.Enumerations("plugin/kinds")
+	assert.EqualError(t, err, "not found")
+	assert.Equal(t, "/enumerations/plugin%2Fkinds.json", paths[2])
+}
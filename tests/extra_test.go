@@ -0,0 +1,45 @@
+package redmine_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtraFieldsRoundTripOnAllModels(t *testing.T) {
+	examples := []struct {
+		name  string
+		model interface{}
+		json  string
+	}{
+		{"project", &lkredmine.Project{}, `{"id":1,"name":"Foo","identifier":"foo","created_on":null,"updated_on":null,"plugin_flag":true}`},
+		{"user", &lkredmine.User{}, `{"id":1,"login":"jdoe","firstname":"John","lastname":"Doe","mail":"","created_on":null,"last_login_on":null,"memberships":null,"avatar":{"url":"x"}}`},
+		{"time entry", &lkredmine.TimeEntry{}, `{"id":1,"project":{"id":1,"name":"Foo"},"issue":{"id":2},"user":{"id":3,"name":"J"},"activity":{"id":4,"name":"Dev"},"hours":1.5,"comments":"","spent_on":"2024-07-11","created_on":null,"updated_on":null,"billable":"yes"}`},
+		{"version", &lkredmine.Version{}, `{"id":1,"project":{"id":1,"name":"Foo"},"name":"1.0","description":"","status":"open","due_date":null,"created_on":null,"updated_on":null,"sharing":"none"}`},
+		{"wiki page", &lkredmine.WikiPage{}, `{"title":"Start","text":"","comments":"","created_on":null,"updated_on":null,"parent_id":0,"protected":true}`},
+		{"membership", &lkredmine.Membership{}, `{"id":1,"project":{"id":1,"name":"Foo"},"user":{"id":2,"name":"J"},"roles":null,"groups":null,"notify":false}`},
+	}
+	for _, example := range examples {
+		err := json.Unmarshal([]byte(example.json), example.model)
+		assert.Nil(t, err, example.name)
+		data, err := json.Marshal(example.model)
+		assert.Nil(t, err, example.name)
+		assert.JSONEq(t, example.json, string(data), example.name)
+	}
+}
+
+func Test_ExtraFieldsAreExposed(t *testing.T) {
+	var project lkredmine.Project
+	err := json.Unmarshal([]byte(`{"id":1,"name":"Foo","plugin_flag":true,"plugin_data":{"a":1}}`), &project)
+	assert.Nil(t, err)
+	assert.Equal(t, "Foo", project.Name)
+	assert.Len(t, project.Extra, 2)
+	assert.Equal(t, true, project.Extra["plugin_flag"])
+
+	project.Extra["plugin_flag"] = false
+	data, err := json.Marshal(project)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"plugin_flag":false`)
+}
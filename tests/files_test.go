@@ -0,0 +1,53 @@
+package redmine_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProjectFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/projects/1/files.json", r.URL.Path)
+		assert.Equal(t, "apikey", r.URL.Query().Get("key"))
+		w.Write([]byte(`{"files":[{"id":4,"filename":"setup.exe","filesize":1024,"content_type":"application/octet-stream","author":{"id":1,"name":"Admin"},"version":{"id":2,"name":"1.0"},"digest":"abc","downloads":3,"created_on":"2024-07-11T10:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	files, err := lkredmine.NewClient(server.URL, "apikey").ProjectFiles(1)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "setup.exe", files[0].Filename)
+	assert.Equal(t, 2, files[0].Version.Id)
+	assert.Equal(t, 3, files[0].Downloads)
+	assert.Equal(t, 2024, files[0].CreatedOn.Year())
+}
+
+func Test_CreateProjectFile(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/projects/1/files.json", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	upload := &lkredmine.Upload{Token: "7167.ed1ccdb0", Filename: "setup.exe"}
+	assert.Nil(t, client.CreateProjectFile(1, upload, 2, "Installer"))
+	assert.Nil(t, client.CreateProjectFile(1, upload, 0, ""))
+	assert.Equal(t, []string{
+		`{"file":{"token":"7167.ed1ccdb0","version_id":2,"filename":"setup.exe","description":"Installer"}}`,
+		`{"file":{"token":"7167.ed1ccdb0","filename":"setup.exe"}}`,
+	}, bodies)
+
+	assert.NotNil(t, client.CreateProjectFile(1, &lkredmine.Upload{}, 0, ""))
+	assert.Len(t, bodies, 2)
+}
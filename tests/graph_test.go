@@ -0,0 +1,108 @@
+package redmine_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/LekoLabs/go-redmine/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeIssueSource struct {
+	issues  map[int]*lkredmine.Issue
+	fetched []int
+}
+
+func (s *fakeIssueSource) IssueWithArgs(id int, args map[string]string) (*lkredmine.Issue, error) {
+	s.fetched = append(s.fetched, id)
+	issue, ok := s.issues[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return issue, nil
+}
+
+func (s *fakeIssueSource) IssuesByFilter(f *lkredmine.IssueFilter) ([]lkredmine.Issue, error) {
+	var issues []lkredmine.Issue
+	for _, issue := range s.issues {
+		if issue.FixedVersion != nil && fmt.Sprint(issue.FixedVersion.Id) == f.ExtraFilters["fixed_version_id"] {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues, nil
+}
+
+func newFakeIssueSource() *fakeIssueSource {
+	delay := 1
+	blocks := lkredmine.IssueRelation{Id: 1, IssueId: 1, IssueToId: 2, RelationType: lkredmine.RelationBlocks}
+	precedes := lkredmine.IssueRelation{Id: 2, IssueId: 2, IssueToId: 3, RelationType: lkredmine.RelationPrecedes, Delay: &delay}
+	shortcut := lkredmine.IssueRelation{Id: 3, IssueId: 1, IssueToId: 3, RelationType: lkredmine.RelationPrecedes}
+	relates := lkredmine.IssueRelation{Id: 4, IssueId: 4, IssueToId: 1, RelationType: lkredmine.RelationRelates}
+	follows := lkredmine.IssueRelation{Id: 5, IssueId: 5, IssueToId: 3, RelationType: lkredmine.RelationFollows}
+	version := &lkredmine.IdName{Id: 7, Name: "1.0"}
+	return &fakeIssueSource{issues: map[int]*lkredmine.Issue{
+		1: {Id: 1, Subject: "Design", EstimatedHours: 4, FixedVersion: version, Relations: []lkredmine.IssueRelation{blocks, shortcut, relates}},
+		2: {Id: 2, Subject: "Build", EstimatedHours: 8, Relations: []lkredmine.IssueRelation{blocks, precedes}},
+		3: {Id: 3, Subject: "Test", EstimatedHours: 2, Relations: []lkredmine.IssueRelation{precedes, shortcut, follows}},
+		4: {Id: 4, Subject: "Unrelated", Relations: []lkredmine.IssueRelation{relates}},
+		5: {Id: 5, Subject: "Ship \"it\"", EstimatedHours: 1, Relations: []lkredmine.IssueRelation{follows}},
+	}}
+}
+
+func Test_GraphCrawlAndOrder(t *testing.T) {
+	src := newFakeIssueSource()
+	g, err := graph.CrawlVersion(src, 7, graph.CrawlOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(g.Nodes()))
+	assert.Nil(t, g.Node(4))
+	assert.Equal(t, 4, len(g.Edges()))
+	assert.Equal(t, graph.Edge{From: 3, To: 5, Type: lkredmine.RelationPrecedes}, g.Edges()[3])
+
+	order, err := g.TopologicalOrder()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 5}, order)
+	assert.Empty(t, g.Cycles())
+
+	path, err := g.CriticalPath(8)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 5}, path.Issues)
+	assert.Equal(t, 23.0, path.Hours)
+
+	_, err = graph.Crawl(src, []int{1}, graph.CrawlOptions{MaxIssues: 2})
+	assert.Equal(t, graph.ErrTooManyIssues, err)
+}
+
+func Test_GraphCycles(t *testing.T) {
+	g, err := graph.Crawl(newFakeIssueSource(), []int{5}, graph.CrawlOptions{})
+	assert.Nil(t, err)
+	g.AddEdge(graph.Edge{From: 5, To: 2, Type: lkredmine.RelationBlocks})
+
+	assert.Equal(t, [][]int{{2, 3, 5}}, g.Cycles())
+	_, err = g.TopologicalOrder()
+	var cycleErr *graph.CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	_, err = g.CriticalPath(8)
+	assert.NotNil(t, err)
+}
+
+func Test_GraphExport(t *testing.T) {
+	g, err := graph.Crawl(newFakeIssueSource(), []int{2}, graph.CrawlOptions{})
+	assert.Nil(t, err)
+
+	dot := g.DOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph issues {"))
+	assert.Contains(t, dot, `1 [label="#1 Design"];`)
+	assert.Contains(t, dot, `2 -> 3 [label="precedes +1d"];`)
+	assert.Contains(t, dot, `5 [label="#5 Ship \"it\""];`)
+
+	g.AddNode(graph.Node{Id: 9, Subject: `Café in C:\tmp`})
+	assert.Contains(t, g.DOT(), `9 [label="#9 Café in C:\\tmp"];`)
+
+	mermaid := g.Mermaid()
+	assert.True(t, strings.HasPrefix(mermaid, "flowchart LR\n"))
+	assert.Contains(t, mermaid, `i5["#5 Ship #quot;it#quot;"]`)
+	assert.Contains(t, mermaid, "i1 -->|blocks| i2")
+}
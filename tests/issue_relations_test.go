@@ -0,0 +1,67 @@
+package redmine_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IssueRelationsEndpoints(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/issues/1/relations.json":
+			w.Write([]byte(`{"relations":[{"id":7,"issue_id":1,"issue_to_id":2,"relation_type":"precedes","delay":3},{"id":8,"issue_id":3,"issue_to_id":1,"relation_type":"relates","delay":null}]}`))
+		case r.Method == "POST" && r.URL.Path == "/issues/1/relations.json":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &created)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"relation":{"id":9,"issue_id":1,"issue_to_id":4,"relation_type":"blocks","delay":null}}`))
+		case r.Method == "GET" && r.URL.Path == "/relations/9.json":
+			w.Write([]byte(`{"relation":{"id":9,"issue_id":1,"issue_to_id":4,"relation_type":"blocks","delay":null}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/relations/9.json":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+
+	relations, err := client.IssueRelations(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(relations))
+	assert.Equal(t, 2, relations[0].IssueToId)
+	assert.Equal(t, lkredmine.RelationPrecedes, relations[0].RelationType)
+	assert.Equal(t, 3, *relations[0].Delay)
+	assert.Nil(t, relations[1].Delay)
+
+	relation, err := client.CreateIssueRelation(lkredmine.IssueRelation{IssueId: 1, IssueToId: 4, RelationType: lkredmine.RelationBlocks})
+	assert.Nil(t, err)
+	assert.Equal(t, 9, relation.Id)
+	assert.Equal(t, map[string]interface{}{"relation": map[string]interface{}{"issue_to_id": float64(4), "relation_type": "blocks"}}, created)
+
+	relation, err = client.IssueRelation(9)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, relation.IssueToId)
+
+	assert.Nil(t, client.DeleteIssueRelation(9))
+}
+
+func Test_IssueRelationValidate(t *testing.T) {
+	delay := 2
+	assert.Nil(t, (&lkredmine.IssueRelation{IssueToId: 2, RelationType: lkredmine.RelationFollows, Delay: &delay}).Validate())
+	assert.NotNil(t, (&lkredmine.IssueRelation{IssueToId: 2, RelationType: lkredmine.RelationBlocks, Delay: &delay}).Validate())
+	assert.NotNil(t, (&lkredmine.IssueRelation{IssueToId: 2, RelationType: "depends"}).Validate())
+	assert.NotNil(t, (&lkredmine.IssueRelation{RelationType: lkredmine.RelationRelates}).Validate())
+	assert.Equal(t, lkredmine.RelationCopiedFrom, lkredmine.InverseRelationType(lkredmine.RelationCopiedTo))
+
+	_, err := lkredmine.NewClient("http://127.0.0.1:0", "apikey").CreateIssueRelation(lkredmine.IssueRelation{IssueId: 1, IssueToId: 2, RelationType: lkredmine.RelationRelates, Delay: &delay})
+	assert.NotNil(t, err)
+}
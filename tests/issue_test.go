@@ -2,7 +2,11 @@ package redmine_test
 
 import (
 	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	gomine "github.com/LekoLabs/go-redmine"
 	"github.com/stretchr/testify/assert"
@@ -74,6 +78,12 @@ func Test_UnmarshalIssueJson(t *testing.T) {
 	assert.Equal(t, 108, issue.Category.Id)
 	assert.Equal(t, "UI", issue.Category.Name)
 
+	// Assert the dates and timestamps
+	assert.Equal(t, "2024-07-12", issue.StartDate.String())
+	assert.Equal(t, gomine.NewDate(2024, 7, 20), issue.DueDate)
+	assert.Equal(t, "2024-07-11T10:00:00Z", issue.CreatedOn.String())
+	assert.True(t, issue.ClosedOn.After(issue.UpdatedOn.Time))
+
 	// Assert the custom fields
 	assert.Len(t, issue.CustomFields, 1)
 	customField := issue.CustomFields[0]
@@ -98,7 +108,7 @@ func Test_UnmarshalIssueJson(t *testing.T) {
 	assert.Equal(t, 201, journal.User.Id)
 	assert.Equal(t, "User1", journal.User.Name)
 	assert.Equal(t, "Started working on the issue", journal.Notes)
-	assert.Equal(t, "2024-07-11T10:15:00Z", journal.CreatedOn)
+	assert.Equal(t, time.Date(2024, 7, 11, 10, 15, 0, 0, time.UTC), journal.CreatedOn.UTC())
 	assert.Len(t, journal.Details, 1)
 	journalDetail := journal.Details[0]
 	assert.Equal(t, "attr", journalDetail.Property)
@@ -131,6 +141,233 @@ func Test_UnmarshalIssueJsonWithExtraFields(t *testing.T) {
 	assert.Equal(t, "single_value", extraField3["single_key"])
 }
 
+func Test_TransitionIssue(t *testing.T) {
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			assert.Equal(t, "/issues/1.json", r.URL.Path)
+			assert.Equal(t, "allowed_statuses", r.URL.Query().Get("include"))
+			w.Write([]byte(`{"issue":{"id":1,"allowed_statuses":[{"id":2,"name":"In Progress","is_closed":false},{"id":5,"name":"Closed","is_closed":true}]}}`))
+		case "PUT":
+			body, _ := io.ReadAll(r.Body)
+			updates = append(updates, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := gomine.NewClient(server.URL, "apikey")
+	allowed, err := client.AllowedStatuses(1)
+	assert.Nil(t, err)
+	assert.Len(t, allowed, 2)
+
+	err = client.TransitionIssue(1, "closed", "Done")
+	assert.Nil(t, err)
+	assert.Len(t, updates, 1)
+	assert.JSONEq(t, `{"issue":{"status_id":5,"notes":"Done"}}`, updates[0])
+
+	err = client.TransitionIssue(1, "Rejected", "")
+	assert.NotNil(t, err)
+	assert.Len(t, updates, 1)
+}
+
+func Test_IssueTreeRollsUpSubtasks(t *testing.T) {
+	issues := map[string]string{
+		"/issues/1.json": `{"issue":{"id":1,"done_ratio":0,"estimated_hours":2,"spent_hours":1,"children":[{"id":2,"children":[{"id":4}]},{"id":3}]}}`,
+		"/issues/2.json": `{"issue":{"id":2,"done_ratio":0,"children":[{"id":4}]}}`,
+		"/issues/3.json": `{"issue":{"id":3,"done_ratio":100,"estimated_hours":6,"spent_hours":5}}`,
+		// a corrupted hierarchy pointing back at the root must not loop
+		"/issues/4.json": `{"issue":{"id":4,"done_ratio":50,"estimated_hours":2,"spent_hours":1,"children":[{"id":1}]}}`,
+	}
+	fetched := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/issue_statuses.json" {
+			w.Write([]byte(issueTreeStatuses))
+			return
+		}
+		fetched[r.URL.Path]++
+		assert.Equal(t, "children", r.URL.Query().Get("include"))
+		w.Write([]byte(issues[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	client := gomine.NewClient(server.URL, "apikey")
+	tree, err := client.IssueTree(1)
+	assert.Nil(t, err)
+	for path := range issues {
+		assert.Equal(t, 1, fetched[path], path)
+	}
+
+	var ids []int
+	tree.Walk(func(node *gomine.IssueNode, depth int) {
+		ids = append(ids, node.Issue.Id)
+	})
+	assert.Equal(t, []int{1, 2, 4, 3}, ids)
+
+	assert.Equal(t, float32(10), tree.EstimatedHours())
+	assert.Equal(t, float32(7), tree.SpentHours())
+	// child 2 weighs 2h at 50%, child 3 weighs 6h at 100%, rounded down like Redmine
+	assert.Equal(t, float32(87), tree.DoneRatio())
+}
+
+const issueTreeStatuses = `{"issue_statuses":[{"id":1,"name":"New","is_closed":false},{"id":5,"name":"Closed","is_closed":true}]}`
+
+func Test_IssueTreeCountsClosedSubtasksAsDone(t *testing.T) {
+	issues := map[string]string{
+		"/issues/1.json": `{"issue":{"id":1,"status":{"id":1,"name":"New"},"done_ratio":0,"children":[{"id":2},{"id":3},{"id":4}]}}`,
+		"/issues/2.json": `{"issue":{"id":2,"status":{"id":5,"name":"Closed"},"done_ratio":0,"estimated_hours":3}}`,
+		"/issues/3.json": `{"issue":{"id":3,"status":{"id":1,"name":"New"},"done_ratio":20,"estimated_hours":3}}`,
+		"/issues/4.json": `{"issue":{"id":4,"status":{"id":1,"name":"New"},"done_ratio":0}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/issue_statuses.json" {
+			w.Write([]byte(issueTreeStatuses))
+			return
+		}
+		w.Write([]byte(issues[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	tree, err := gomine.NewClient(server.URL, "apikey").IssueTree(1)
+	assert.Nil(t, err)
+	assert.True(t, tree.Children[0].Closed)
+	assert.False(t, tree.Closed)
+	// (3h * 100% + 3h * 20% + 3h average * 0%) / (3 children * 3h average) = 40
+	assert.Equal(t, float32(40), tree.DoneRatio())
+}
+
+func Test_DateNullHandling(t *testing.T) {
+	var entry gomine.TimeEntry
+	err := json.Unmarshal([]byte(`{"id":1,"spent_on":"2024-07-11","created_on":null,"updated_on":""}`), &entry)
+	assert.Nil(t, err)
+	assert.Equal(t, gomine.NewDate(2024, 7, 11), entry.SpentOn)
+	assert.True(t, entry.CreatedOn.IsZero())
+	assert.True(t, entry.UpdatedOn.IsZero())
+
+	data, err := json.Marshal(gomine.Version{Name: "1.0"})
+	assert.Nil(t, err)
+	var fields map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &fields))
+	assert.Nil(t, fields["due_date"])
+	assert.Contains(t, fields, "due_date")
+
+	err = json.Unmarshal([]byte(`{"due_date":"20/07/2024"}`), &gomine.Version{})
+	assert.NotNil(t, err)
+}
+
+func Test_TimestampKeepsDateOnlyLayout(t *testing.T) {
+	var issue gomine.Issue
+	assert.Nil(t, json.Unmarshal([]byte(`{"id":1,"created_on":"2024-07-11T10:00:00Z","closed_on":"2024-07-21"}`), &issue))
+	assert.Equal(t, 21, issue.ClosedOn.Day())
+
+	data, err := json.Marshal(issue)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"closed_on":"2024-07-21"`)
+	assert.Contains(t, string(data), `"created_on":"2024-07-11T10:00:00Z"`)
+}
+
+func Test_VersionAndTimeEntryWritesOmitTimestamps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]map[string]interface{}
+		assert.Nil(t, json.Unmarshal(body, &req))
+		for _, fields := range req {
+			assert.Contains(t, fields, "id")
+			assert.NotContains(t, fields, "created_on")
+			assert.NotContains(t, fields, "updated_on")
+		}
+	}))
+	defer server.Close()
+
+	client := gomine.NewClient(server.URL, "apikey")
+	created := gomine.Timestamp{Time: time.Date(2024, 7, 11, 10, 0, 0, 0, time.UTC)}
+	assert.Nil(t, client.UpdateVersion(gomine.Version{Id: 1, Name: "1.0", CreatedOn: created, UpdatedOn: created}))
+	assert.Nil(t, client.UpdateTimeEntry(gomine.TimeEntry{Id: 2, Hours: 1.5, CreatedOn: created, UpdatedOn: created}))
+}
+
+func Test_IssueToUpdateRoundTrip(t *testing.T) {
+	var issue gomine.Issue
+	err := json.Unmarshal([]byte(exampleIssueStruct()), &issue)
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(gomine.IssueCreationRequest{Issue: issue.ToUpdate()})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"issue": {
+		"id": 1,
+		"project_id": 101,
+		"tracker_id": 102,
+		"status_id": 103,
+		"priority_id": 104,
+		"subject": "Sample Issue",
+		"description": "This is a sample issue",
+		"category_id": 108,
+		"fixed_version_id": 106,
+		"assigned_to_id": 107,
+		"parent_issue_id": 2,
+		"custom_fields": [{"id": 1, "name": "Severity", "description": "", "multiple": false, "value": "Critical"}],
+		"is_private": false,
+		"start_date": "2024-07-12",
+		"due_date": "2024-07-20",
+		"estimated_hours": 5,
+		"done_ratio": 50
+	}}`, string(data))
+}
+
+func Test_IssueToCreateZeroAndClearedValues(t *testing.T) {
+	hours := float32(0)
+	data, err := json.Marshal(gomine.IssueToCreate{
+		Id:             1,
+		EstimatedHours: &hours,
+	})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id": 1, "estimated_hours": 0}`, string(data))
+
+	data, err = json.Marshal(gomine.NewIssuePatch().ClearAssignedTo().ClearDueDate())
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"assigned_to_id": "", "due_date": ""}`, string(data))
+}
+
+func Test_IssueToUpdateCopiesWatchersAndCustomFields(t *testing.T) {
+	var issue gomine.Issue
+	err := json.Unmarshal([]byte(`{"id":1,"subject":"S","watchers":[{"id":5,"name":"A"},{"id":6,"name":"B"}],
+		"custom_fields":[{"id":2,"name":"Reviewers","multiple":true,"value":["5","6"]}]}`), &issue)
+	assert.Nil(t, err)
+
+	update := issue.ToUpdate()
+	assert.Equal(t, []int{5, 6}, update.WatcherUserIds)
+	assert.Equal(t, []string{"5", "6"}, update.CustomFields[0].Values())
+
+	// the conversion must not alias the values of the original issue
+	update.CustomFields[0].Value.([]interface{})[0] = "7"
+	assert.Equal(t, []string{"5", "6"}, issue.CustomFields[0].Values())
+}
+
+func Test_IssueDiff(t *testing.T) {
+	var original gomine.Issue
+	err := json.Unmarshal([]byte(exampleIssueStruct()), &original)
+	assert.Nil(t, err)
+
+	var modified gomine.Issue
+	err = json.Unmarshal([]byte(exampleIssueStruct()), &modified)
+	assert.Nil(t, err)
+	assert.True(t, original.Diff(&modified).IsEmpty())
+
+	modified.Status = &gomine.IdName{Id: 5, Name: "Closed"}
+	modified.AssignedTo = nil
+	modified.DueDate = gomine.Date{}
+	modified.SetCustomField(1, "Minor")
+
+	data, err := json.Marshal(original.Diff(&modified))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"status_id": 5,
+		"assigned_to_id": "",
+		"due_date": "",
+		"custom_fields": [{"id": 1, "name": "", "description": "", "multiple": false, "value": "Minor"}]
+	}`, string(data))
+}
+
 func exampleIssueStruct() string {
 	return `{
 	"id": 1,
@@ -266,6 +503,7 @@ func exampleIssueStructWithExtraFields() string {
 	"start_date": "2024-07-12",
 	"due_date": "2024-07-20",
 	"closed_on": "2024-07-21",
+	"is_private": false,
 	"custom_fields": [
 		{
 			"id": 1,
@@ -312,3 +550,11 @@ func exampleIssueStructWithExtraFields() string {
 }
 `
 }
+
+func Test_IssueKeepsIsPrivateFalse(t *testing.T) {
+	var issue gomine.Issue
+	assert.Nil(t, json.Unmarshal([]byte(`{"id":1,"is_private":false}`), &issue))
+	data, err := json.Marshal(issue)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"is_private":false`)
+}
@@ -0,0 +1,57 @@
+package redmine_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IssueNotesAndJournals(t *testing.T) {
+	bodies := make(map[string][]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		bodies[r.URL.Path] = append(bodies[r.URL.Path], string(body))
+		if r.URL.Path == "/journals/8.json" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors":["Notes cannot be blank"]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+
+	assert.Nil(t, client.AddIssueNotes(1, "public note", false))
+	assert.Nil(t, client.AddIssueNotes(1, "private note", true))
+	assert.Nil(t, client.UpdateIssue(lkredmine.IssueToCreate{Id: 1, Notes: "via update", PrivateNotes: true}))
+	assert.Equal(t, []string{
+		`{"issue":{"notes":"public note"}}`,
+		`{"issue":{"notes":"private note","private_notes":true}}`,
+		`{"issue":{"id":1,"notes":"via update","private_notes":true}}`,
+	}, bodies["/issues/1.json"])
+
+	assert.Nil(t, client.UpdateJournal(7, "fixed typo"))
+	assert.Equal(t, []string{`{"journal":{"notes":"fixed typo"}}`}, bodies["/journals/7.json"])
+
+	assert.EqualError(t, client.UpdateJournal(8, ""), "Notes cannot be blank")
+}
+
+func Test_JournalPrivateNotesDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "journals", r.URL.Query().Get("include"))
+		w.Write([]byte(`{"issue":{"id":1,"journals":[{"id":7,"notes":"secret","private_notes":true},{"id":9,"notes":"hello","private_notes":false}]}}`))
+	}))
+	defer server.Close()
+
+	issue, err := lkredmine.NewClient(server.URL, "apikey").IssueWithArgs(1, map[string]string{"include": "journals"})
+	assert.Nil(t, err)
+	assert.Len(t, issue.Journals, 2)
+	assert.True(t, issue.Journals[0].PrivateNotes)
+	assert.False(t, issue.Journals[1].PrivateNotes)
+}
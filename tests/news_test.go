@@ -0,0 +1,65 @@
+package redmine_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AllNewsFetchesEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/news.json", r.URL.Path)
+		offset := r.URL.Query().Get("offset")
+		id := 1
+		if offset == "1" {
+			id = 2
+		}
+		fmt.Fprintf(w, `{"news":[{"id":%d,"project":{"id":1,"name":"Foo"},"author":{"id":3,"name":"Jane"},"title":"Release"}],"total_count":2}`, id)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	news, err := client.AllNews()
+	assert.Nil(t, err)
+	assert.Len(t, news, 2)
+	assert.Equal(t, 2, news[1].Id)
+	assert.Equal(t, "Jane", news[0].Author.Name)
+}
+
+func Test_CreateNews(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/projects/1/news.json", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"news":{"title":"1.0 released","summary":"","description":"Changelog","uploads":[{"token":"abc","filename":"notes.txt","content_type":""}]}}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	err := client.CreateNews(1, lkredmine.News{
+		Title:       "1.0 released",
+		Description: "Changelog",
+		Uploads:     []*lkredmine.Upload{{Token: "abc", Filename: "notes.txt"}},
+	})
+	assert.Nil(t, err)
+}
+
+func Test_UpdateNewsClearsSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/news/3.json", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"news":{"title":"1.0 released","summary":""}}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	assert.Nil(t, client.UpdateNews(lkredmine.News{Id: 3, Title: "1.0 released"}))
+}
@@ -0,0 +1,60 @@
+package redmine_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IssuePatchOnlySendsSetFields(t *testing.T) {
+	patch := lkredmine.NewIssuePatch().
+		SetStatusId(3).
+		SetEstimatedHours(0).
+		ClearAssignedTo().
+		SetCustomField(5, "Critical")
+
+	data, err := json.Marshal(patch)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"status_id": 3,
+		"estimated_hours": 0,
+		"assigned_to_id": "",
+		"custom_fields": [{"id": 5, "name": "", "description": "", "multiple": false, "value": "Critical"}]
+	}`, string(data))
+	assert.False(t, patch.IsEmpty())
+	assert.True(t, lkredmine.NewIssuePatch().IsEmpty())
+}
+
+func Test_PatchIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/issues/42.json", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"issue":{"status_id":5,"notes":"closing"}}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	err := client.PatchIssue(42, lkredmine.NewIssuePatch().SetStatusId(5).SetNotes("closing"))
+	assert.Nil(t, err)
+}
+
+func Test_PatchVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/versions/7.json", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"version":{"status":"closed","due_date":""}}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	err := client.PatchVersion(7, lkredmine.NewVersionPatch().SetStatus("closed").ClearDueDate())
+	assert.Nil(t, err)
+}
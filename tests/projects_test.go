@@ -1,6 +1,10 @@
 package redmine_test
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,3 +75,95 @@ func Test_GetListProjects(t *testing.T) {
 	}
 	t.Log("Environment variables not found; test not run.")
 }
+
+func Test_ProjectTree(t *testing.T) {
+	projects := []lkredmine.Project{
+		{Id: 1, Name: "Root"},
+		{Id: 2, Name: "Child", Parent: &lkredmine.IdName{Id: 1, Name: "Root"}},
+		{Id: 3, Name: "Grandchild", Parent: &lkredmine.IdName{Id: 2, Name: "Child"}},
+		{Id: 4, Name: "Orphan", Parent: &lkredmine.IdName{Id: 99, Name: "Hidden"}},
+	}
+	roots := lkredmine.ProjectTree(projects)
+	assert.Len(t, roots, 2)
+	assert.Equal(t, 1, roots[0].Project.Id)
+	assert.Equal(t, 4, roots[1].Project.Id)
+	assert.Len(t, roots[0].Children, 1)
+	assert.Equal(t, 2, roots[0].Children[0].Project.Id)
+	assert.Len(t, roots[0].Children[0].Children, 1)
+	assert.Equal(t, 3, roots[0].Children[0].Children[0].Project.Id)
+}
+
+func Test_ProjectWithIncludes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/foo.json", r.URL.Path)
+		assert.Equal(t, "trackers,enabled_modules", r.URL.Query().Get("include"))
+		w.Write([]byte(`{"project":{"id":1,"name":"Foo","identifier":"foo","status":1,
+			"default_version":{"id":3,"name":"1.0"},
+			"trackers":[{"id":1,"name":"Bug"}],
+			"enabled_modules":[{"id":10,"name":"issue_tracking"}]}}`))
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	project, err := client.Project("foo", lkredmine.ProjectIncludeTrackers, lkredmine.ProjectIncludeEnabledModules)
+	assert.Nil(t, err)
+	assert.Nil(t, project.Parent)
+	assert.Equal(t, lkredmine.ProjectStatusActive, project.Status)
+	assert.Equal(t, 3, project.DefaultVersion.Id)
+	assert.Equal(t, []lkredmine.IdName{{Id: 1, Name: "Bug"}}, project.Trackers)
+	assert.Equal(t, "issue_tracking", project.EnabledModules[0].Name)
+}
+
+func Test_ProjectWritesOmitTimestamps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			Project map[string]interface{} `json:"project"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &req))
+		assert.Equal(t, "Website", req.Project["name"])
+		assert.NotContains(t, req.Project, "created_on")
+		assert.NotContains(t, req.Project, "updated_on")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	assert.Nil(t, client.UpdateProject(lkredmine.Project{Id: 1, Name: "Website", Identifier: "web"}))
+
+	// the model itself still round-trips its timestamps
+	data, err := json.Marshal(lkredmine.Project{Id: 1})
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"created_on":null`)
+}
+
+func Test_ProjectLifecycle(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		assert.Equal(t, "admin", r.Header.Get("X-Redmine-Switch-User"))
+		switch r.URL.Path {
+		case "/projects/gone/archive.json":
+			w.WriteHeader(http.StatusNotFound)
+		case "/projects/web/close.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	assert.Nil(t, client.ArchiveProject("web", "admin"))
+	assert.Nil(t, client.UnarchiveProject("web", "admin"))
+	assert.Nil(t, client.ReopenProject("web", "admin"))
+	assert.EqualError(t, client.CloseProject("web", "admin"), "Forbidden")
+	assert.EqualError(t, client.ArchiveProject("gone", "admin"), "not found")
+	assert.Equal(t, []string{
+		"PUT /projects/web/archive.json",
+		"PUT /projects/web/unarchive.json",
+		"PUT /projects/web/reopen.json",
+		"PUT /projects/web/close.json",
+		"PUT /projects/gone/archive.json",
+	}, calls)
+}
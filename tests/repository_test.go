@@ -0,0 +1,29 @@
+package redmine_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LinkIssueToRevision(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	assert.Nil(t, client.AddRelatedIssueToRevision("foo", "backend", "abc123", 7))
+	assert.Nil(t, client.RemoveRelatedIssueFromRevision("foo", "", "abc123", 7))
+	assert.Equal(t, []string{
+		`POST /projects/foo/repository/backend/revisions/abc123/issues.json {"issue_id":7}`,
+		`DELETE /projects/foo/repository/revisions/abc123/issues/7.json `,
+	}, calls)
+}
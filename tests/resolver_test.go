@@ -0,0 +1,133 @@
+package redmine_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResolverServer(t *testing.T, calls map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/trackers.json":
+			w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"},{"id":2,"name":"Feature"},{"id":3,"name":"feature"}]}`))
+		case "/projects.json":
+			w.Write([]byte(`{"projects":[{"id":1,"name":"Website","identifier":"web"},{"id":2,"name":"Backend","identifier":"api"}]}`))
+		case "/users.json":
+			w.Write([]byte(`{"users":[{"id":5,"login":"jdoe","firstname":"John","lastname":"Doe"},{"id":6,"login":"jdoe2","firstname":"John","lastname":"Doe"}]}`))
+		case "/projects/1/versions.json":
+			w.Write([]byte(`{"versions":[{"id":10,"name":"1.0"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_ResolverResolvesNamesAndIds(t *testing.T) {
+	calls := make(map[string]int)
+	server := newResolverServer(t, calls)
+	defer server.Close()
+
+	resolver := lkredmine.NewResolver(lkredmine.NewClient(server.URL, "apikey"), time.Hour)
+
+	id, err := resolver.TrackerId("bug")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, id)
+
+	// the exact match wins over the case-insensitive one
+	id, err = resolver.TrackerId("feature")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, id)
+
+	name, err := resolver.TrackerName(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "Feature", name)
+	assert.Equal(t, 1, calls["/trackers.json"])
+
+	id, err = resolver.ProjectId("api")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, id)
+
+	id, err = resolver.UserId("jdoe2")
+	assert.Nil(t, err)
+	assert.Equal(t, 6, id)
+
+	_, err = resolver.UserId("John Doe")
+	var ambiguous *lkredmine.AmbiguousNameError
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, []int{5, 6}, ambiguous.Ids)
+
+	_, err = resolver.VersionId(1, "2.0")
+	var unknown *lkredmine.UnknownNameError
+	assert.True(t, errors.As(err, &unknown))
+
+	_, err = resolver.TrackerName(42)
+	var unknownId *lkredmine.UnknownIdError
+	assert.True(t, errors.As(err, &unknownId))
+}
+
+func Test_ResolverCacheExpires(t *testing.T) {
+	calls := make(map[string]int)
+	server := newResolverServer(t, calls)
+	defer server.Close()
+
+	resolver := lkredmine.NewResolver(lkredmine.NewClient(server.URL, "apikey"), 20*time.Millisecond)
+	resolver.TrackerId("Bug")
+	resolver.TrackerId("Bug")
+	assert.Equal(t, 1, calls["/trackers.json"])
+
+	time.Sleep(30 * time.Millisecond)
+	resolver.TrackerId("Bug")
+	assert.Equal(t, 2, calls["/trackers.json"])
+
+	resolver.Invalidate()
+	resolver.TrackerId("Bug")
+	assert.Equal(t, 3, calls["/trackers.json"])
+}
+
+func Test_ResolverLoadsKeysIndependently(t *testing.T) {
+	release := make(chan struct{})
+	var trackerCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/trackers.json":
+			atomic.AddInt32(&trackerCalls, 1)
+			<-release
+			w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+		case "/projects.json":
+			w.Write([]byte(`{"projects":[{"id":2,"name":"Backend","identifier":"api"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := lkredmine.NewResolver(lkredmine.NewClient(server.URL, "apikey"), time.Hour)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := resolver.TrackerId("Bug")
+			assert.Nil(t, err)
+			assert.Equal(t, 1, id)
+		}()
+	}
+
+	// projects resolve while the trackers are still loading
+	id, err := resolver.ProjectId("api")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, id)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&trackerCalls))
+}
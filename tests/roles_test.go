@@ -0,0 +1,34 @@
+package redmine_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Role(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/roles/3.json":
+			w.Write([]byte(`{"role":{"id":3,"name":"Developer","assignable":true,"issues_visibility":"default","time_entries_visibility":"all","users_visibility":"members_of_visible_projects","permissions":["view_issues","add_issues","edit_issues"]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	role, err := client.Role(3)
+	assert.Nil(t, err)
+	assert.Equal(t, "Developer", role.Name)
+	assert.True(t, role.Assignable)
+	assert.Equal(t, "default", role.IssuesVisibility)
+	assert.True(t, role.HasPermission("edit_issues"))
+	assert.False(t, role.HasPermission("delete_issues"))
+
+	_, err = client.Role(99)
+	assert.EqualError(t, err, "not found")
+}
@@ -0,0 +1,46 @@
+package redmine_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SearchSendsOptionsAndDecodesResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/foo%2Fbar/search.json", r.URL.EscapedPath())
+		q := r.URL.Query()
+		assert.Equal(t, "disk full", q.Get("q"))
+		assert.Equal(t, "1", q.Get("issues"))
+		assert.Equal(t, "1", q.Get("titles_only"))
+		assert.Equal(t, "", q.Get("news"))
+		assert.Equal(t, "25", q.Get("limit"))
+		assert.Equal(t, "apikey", q.Get("key"))
+		w.Write([]byte(`{"results":[{"id":7,"title":"Bug #7: disk full","type":"issue","url":"http://x/issues/7","description":"","datetime":"2024-07-11T10:00:00Z"}],"total_count":1,"offset":0,"limit":25}`))
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	page, err := client.Search(context.Background(), lkredmine.SearchOptions{
+		Query:      "disk full",
+		ProjectId:  "foo/bar",
+		TitlesOnly: true,
+		Issues:     true,
+		Limit:      25,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, page.TotalCount)
+	assert.Len(t, page.Results, 1)
+	assert.Equal(t, 7, page.Results[0].Id)
+	assert.Equal(t, "issue", page.Results[0].Type)
+}
+
+func Test_SearchRejectsEmptyQuery(t *testing.T) {
+	client := lkredmine.NewClient("http://localhost", "apikey")
+	_, err := client.Search(context.Background(), lkredmine.SearchOptions{})
+	assert.NotNil(t, err)
+}
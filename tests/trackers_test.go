@@ -0,0 +1,61 @@
+package redmine_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTrackersServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/trackers.json":
+			w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug","default_status":{"id":1,"name":"New"},"description":"Defects","enabled_standard_fields":["assigned_to_id","due_date"]},{"id":2,"name":"Feature","default_status":{"id":1,"name":"New"}}]}`))
+		case "/custom_fields.json":
+			w.Write([]byte(`{"custom_fields":[
+				{"id":1,"name":"Severity","customized_type":"issue","field_format":"list","trackers":[{"id":1,"name":"Bug"}]},
+				{"id":2,"name":"Customer","customized_type":"issue","field_format":"string","trackers":[{"id":1,"name":"Bug"},{"id":2,"name":"Feature"}]},
+				{"id":3,"name":"Department","customized_type":"user","field_format":"string"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_Trackers(t *testing.T) {
+	server := newTrackersServer(t)
+	defer server.Close()
+
+	trackers, err := lkredmine.NewClient(server.URL, "apikey").Trackers()
+	assert.Nil(t, err)
+	assert.Len(t, trackers, 2)
+	assert.Equal(t, "Bug", trackers[0].Name)
+	assert.Equal(t, "New", trackers[0].DefaultStatus.Name)
+	assert.Equal(t, "Defects", trackers[0].Description)
+
+	assert.True(t, trackers[0].EnablesField("due_date"))
+	assert.False(t, trackers[0].EnablesField("estimated_hours"))
+	// older Redmine versions send no field list
+	assert.Nil(t, trackers[1].EnabledStandardFields)
+	assert.True(t, trackers[1].EnablesField("estimated_hours"))
+}
+
+func Test_TrackerCustomFields(t *testing.T) {
+	server := newTrackersServer(t)
+	defer server.Close()
+
+	fields, err := lkredmine.NewClient(server.URL, "apikey").TrackerCustomFields(2)
+	assert.Nil(t, err)
+	assert.Len(t, fields, 1)
+	assert.Equal(t, "Customer", fields[0].Name)
+
+	definitions := []lkredmine.CustomFieldDefinition{
+		{Id: 1, CustomizedType: "issue", Trackers: []lkredmine.IdName{{Id: 1}}},
+		{Id: 2, CustomizedType: "issue", Trackers: []lkredmine.IdName{{Id: 1}, {Id: 2}}},
+	}
+	tracker := lkredmine.Tracker{Id: 1}
+	assert.Len(t, tracker.CustomFields(definitions), 2)
+}
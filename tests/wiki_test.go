@@ -0,0 +1,109 @@
+package redmine_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lkredmine "github.com/LekoLabs/go-redmine"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WikiPageVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "attachments", r.URL.Query().Get("include"))
+		switch r.URL.Path {
+		case "/projects/1/wiki/Start.json":
+			w.Write([]byte(`{"wiki_page":{"title":"Start","version":3,"comments":"third","attachments":[{"id":9,"filename":"a.png"}]}}`))
+		case "/projects/1/wiki/Start/1.json":
+			w.Write([]byte(`{"wiki_page":{"title":"Start","version":1,"comments":"first"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	page, err := client.WikiPage(1, "Start")
+	assert.Nil(t, err)
+	assert.Equal(t, "a.png", page.Attachments[0].Filename)
+
+	versions, err := client.WikiPageVersions(1, "Start", 0)
+	assert.Nil(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, 1, versions[0].Version)
+	assert.Equal(t, "third", versions[1].Comments)
+
+	versions, err = client.WikiPageVersions(1, "Start", 2)
+	assert.Nil(t, err)
+	assert.Len(t, versions, 1)
+	assert.Equal(t, 3, versions[0].Version)
+
+	_, err = client.WikiPage(1, "Missing")
+	assert.True(t, errors.Is(err, lkredmine.ErrNotFound))
+}
+
+func Test_WikiPageTitleIsEscaped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/1/wiki/Q&A%3F%20100%25/2.json", r.URL.EscapedPath())
+		w.Write([]byte(`{"wiki_page":{"title":"Q&A? 100%","version":2}}`))
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	page, err := client.WikiPageAtVersion(1, "Q&A? 100%", "2")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, page.Version)
+}
+
+func Test_RenameWikiPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/1/wiki/Old%20page.json", r.URL.EscapedPath())
+		if r.Method == "GET" {
+			w.Write([]byte(`{"wiki_page":{"title":"Old_page","text":"h1. Old","version":4}}`))
+			return
+		}
+		assert.Equal(t, "PUT", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"wiki_page":{"title":"New page","redirect_existing_links":"1","text":"h1. Old","version":4}}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	assert.Nil(t, client.RenameWikiPage(1, "Old page", "New page", true))
+}
+
+func Test_UpdateWikiPageConflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	err := client.UpdateWikiPage(1, lkredmine.WikiPage{Title: "Start", Text: "new", Version: 2})
+	var conflict *lkredmine.WikiPageConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, 2, conflict.Version)
+}
+
+func Test_WikiPageWritesOmitTimestamps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			WikiPage map[string]interface{} `json:"wiki_page"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &req))
+		assert.Equal(t, "new", req.WikiPage["text"])
+		assert.NotContains(t, req.WikiPage, "created_on")
+		assert.NotContains(t, req.WikiPage, "updated_on")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := lkredmine.NewClient(server.URL, "apikey")
+	assert.Nil(t, client.UpdateWikiPage(1, lkredmine.WikiPage{Title: "Start", Text: "new"}))
+}
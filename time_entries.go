@@ -20,18 +20,45 @@ type timeEntryRequest struct {
 	TimeEntry TimeEntry `json:"time_entry"`
 }
 
+// MarshalJSON leaves the read-only timestamps out of create and update requests.
+func (r timeEntryRequest) MarshalJSON() ([]byte, error) {
+	timeEntry, err := marshalWithout(r.TimeEntry, "created_on", "updated_on")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		TimeEntry json.RawMessage `json:"time_entry"`
+	}{timeEntry})
+}
+
 type TimeEntry struct {
-	Id           int            `json:"id"`
-	Project      IdName         `json:"project"`
-	Issue        Id             `json:"issue"`
-	User         IdName         `json:"user"`
-	Activity     IdName         `json:"activity"`
-	Hours        float32        `json:"hours"`
-	Comments     string         `json:"comments"`
-	SpentOn      string         `json:"spent_on"`
-	CreatedOn    string         `json:"created_on"`
-	UpdatedOn    string         `json:"updated_on"`
-	CustomFields []*CustomField `json:"custom_fields,omitempty"`
+	Id           int                    `json:"id"`
+	Project      IdName                 `json:"project"`
+	Issue        Id                     `json:"issue"`
+	User         IdName                 `json:"user"`
+	Activity     IdName                 `json:"activity"`
+	Hours        float32                `json:"hours"`
+	Comments     string                 `json:"comments"`
+	SpentOn      Date                   `json:"spent_on"`
+	CreatedOn    Timestamp              `json:"created_on"`
+	UpdatedOn    Timestamp              `json:"updated_on"`
+	CustomFields []*CustomField         `json:"custom_fields,omitempty"`
+	Extra        map[string]interface{} `json:"-"`
+}
+
+func (timeEntry TimeEntry) MarshalJSON() ([]byte, error) {
+	type Alias TimeEntry
+	return marshalExtra(Alias(timeEntry), timeEntry.Extra)
+}
+
+func (timeEntry *TimeEntry) UnmarshalJSON(data []byte) error {
+	type Alias TimeEntry
+	extra, err := unmarshalExtra(data, (*Alias)(timeEntry))
+	if err != nil {
+		return err
+	}
+	timeEntry.Extra = extra
+	return nil
 }
 
 // TimeEntriesWithFilter send query and return parsed result
@@ -54,7 +81,7 @@ func (c *Client) TimeEntriesWithFilter(filter Filter) ([]TimeEntry, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r timeEntriesResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -81,7 +108,7 @@ func (c *Client) TimeEntries(projectId int) ([]TimeEntry, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r timeEntriesResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -108,7 +135,7 @@ func (c *Client) TimeEntry(id int) (*TimeEntry, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r timeEntryResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -138,7 +165,7 @@ func (c *Client) CreateTimeEntry(timeEntry TimeEntry, userName ...string) (*Time
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -176,7 +203,7 @@ func (c *Client) UpdateTimeEntry(timeEntry TimeEntry, userName ...string) error
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -185,7 +212,7 @@ func (c *Client) UpdateTimeEntry(timeEntry TimeEntry, userName ...string) error
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		decoder := json.NewDecoder(res.Body)
@@ -208,7 +235,7 @@ func (c *Client) DeleteTimeEntry(id int, userName ...string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -217,7 +244,7 @@ func (c *Client) DeleteTimeEntry(id int, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
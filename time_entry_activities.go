@@ -1,41 +1,20 @@
 package redmine
 
-import (
-	"encoding/json"
-	"errors"
-	"strings"
-)
-
-type timeEntryActivitiesResult struct {
-	TimeEntryActivites []TimeEntryActivity `json:"time_entry_activities"`
-}
-
 type TimeEntryActivity struct {
 	Id        int    `json:"id"`
 	Name      string `json:"name"`
 	IsDefault bool   `json:"is_default"`
+	Active    bool   `json:"active"`
 }
 
 func (c *Client) TimeEntryActivities() ([]TimeEntryActivity, error) {
-	res, err := c.Get(c.endpoint + "/enumerations/time_entry_activities.json?key=" + c.apikey + c.getPaginationClause())
+	enumerations, err := c.Enumerations(EnumerationTimeEntryActivities)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
-
-	decoder := json.NewDecoder(res.Body)
-	var r timeEntryActivitiesResult
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
-	if err != nil {
-		return nil, err
+	activities := make([]TimeEntryActivity, len(enumerations))
+	for i, e := range enumerations {
+		activities[i] = TimeEntryActivity{Id: e.Id, Name: e.Name, IsDefault: e.IsDefault, Active: e.Active}
 	}
-	return r.TimeEntryActivites, nil
+	return activities, nil
 }
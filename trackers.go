@@ -3,16 +3,64 @@ package redmine
 import (
 	"encoding/json"
 	"errors"
-	"net/http"
 	"strings"
 )
 
 type trackersResult struct {
-	Trackers []IdName `json:"trackers"`
+	Trackers []Tracker `json:"trackers"`
 }
 
-func (c *Client) Trackers() ([]IdName, error) {
-	res, err := http.Get(c.endpoint + "/trackers.json?key=" + c.apikey + c.getPaginationClause())
+type Tracker struct {
+	Id                    int      `json:"id"`
+	Name                  string   `json:"name"`
+	DefaultStatus         *IdName  `json:"default_status,omitempty"`
+	Description           string   `json:"description"`
+	EnabledStandardFields []string `json:"enabled_standard_fields"`
+}
+
+// EnablesField reports whether the tracker uses the given standard issue field,
+// e.g. "assigned_to_id", "due_date" or "estimated_hours".
+// Redmine versions older than 3.4 do not send the field list; every field is then
+// assumed to be enabled.
+func (t *Tracker) EnablesField(field string) bool {
+	if t.EnabledStandardFields == nil {
+		return true
+	}
+	for _, f := range t.EnabledStandardFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomFields returns the issue custom fields enabled for the tracker among the
+// given definitions. Redmine does not list custom fields in /trackers.json, so
+// the association is derived from CustomFieldDefinition.Trackers; the
+// definitions come from Client.CustomFields, which requires admin privileges.
+func (t *Tracker) CustomFields(definitions []CustomFieldDefinition) []CustomFieldDefinition {
+	var fields []CustomFieldDefinition
+	for _, d := range definitions {
+		if d.CustomizedType == "issue" && d.EnabledForTracker(t.Id) {
+			fields = append(fields, d)
+		}
+	}
+	return fields
+}
+
+// TrackerCustomFields fetches the issue custom fields enabled for the given tracker.
+// Requires admin privileges.
+func (c *Client) TrackerCustomFields(trackerId int) ([]CustomFieldDefinition, error) {
+	definitions, err := c.CustomFields()
+	if err != nil {
+		return nil, err
+	}
+	t := Tracker{Id: trackerId}
+	return t.CustomFields(definitions), nil
+}
+
+func (c *Client) Trackers() ([]Tracker, error) {
+	res, err := c.Get(c.endpoint + "/trackers.json?key=" + c.apikey + c.getPaginationClause())
 	if err != nil {
 		return nil, err
 	}
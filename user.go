@@ -17,15 +17,31 @@ type usersResult struct {
 }
 
 type User struct {
-	Id           int            `json:"id"`
-	Login        string         `json:"login"`
-	Firstname    string         `json:"firstname"`
-	Lastname     string         `json:"lastname"`
-	Mail         string         `json:"mail"`
-	CreatedOn    string         `json:"created_on"`
-	LatLoginOn   string         `json:"last_login_on"`
-	Memberships  []Membership   `json:"memberships"`
-	CustomFields []*CustomField `json:"custom_fields,omitempty"`
+	Id           int                    `json:"id"`
+	Login        string                 `json:"login"`
+	Firstname    string                 `json:"firstname"`
+	Lastname     string                 `json:"lastname"`
+	Mail         string                 `json:"mail"`
+	CreatedOn    Timestamp              `json:"created_on"`
+	LatLoginOn   Timestamp              `json:"last_login_on"`
+	Memberships  []Membership           `json:"memberships"`
+	CustomFields []*CustomField         `json:"custom_fields,omitempty"`
+	Extra        map[string]interface{} `json:"-"`
+}
+
+func (user User) MarshalJSON() ([]byte, error) {
+	type Alias User
+	return marshalExtra(Alias(user), user.Extra)
+}
+
+func (user *User) UnmarshalJSON(data []byte) error {
+	type Alias User
+	extra, err := unmarshalExtra(data, (*Alias)(user))
+	if err != nil {
+		return err
+	}
+	user.Extra = extra
+	return nil
 }
 
 type UsersFilter struct {
@@ -12,6 +12,17 @@ type versionRequest struct {
 	Version Version `json:"version"`
 }
 
+// MarshalJSON leaves the read-only timestamps out of create and update requests.
+func (r versionRequest) MarshalJSON() ([]byte, error) {
+	version, err := marshalWithout(r.Version, "created_on", "updated_on")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Version json.RawMessage `json:"version"`
+	}{version})
+}
+
 type versionResult struct {
 	Version Version `json:"version"`
 }
@@ -21,15 +32,31 @@ type versionsResult struct {
 }
 
 type Version struct {
-	Id           int            `json:"id"`
-	Project      IdName         `json:"project"`
-	Name         string         `json:"name"`
-	Description  string         `json:"description"`
-	Status       string         `json:"status"`
-	DueDate      string         `json:"due_date"`
-	CreatedOn    string         `json:"created_on"`
-	UpdatedOn    string         `json:"updated_on"`
-	CustomFields []*CustomField `json:"custom_fields,omitempty"`
+	Id           int                    `json:"id"`
+	Project      IdName                 `json:"project"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Status       string                 `json:"status"`
+	DueDate      Date                   `json:"due_date"`
+	CreatedOn    Timestamp              `json:"created_on"`
+	UpdatedOn    Timestamp              `json:"updated_on"`
+	CustomFields []*CustomField         `json:"custom_fields,omitempty"`
+	Extra        map[string]interface{} `json:"-"`
+}
+
+func (version Version) MarshalJSON() ([]byte, error) {
+	type Alias Version
+	return marshalExtra(Alias(version), version.Extra)
+}
+
+func (version *Version) UnmarshalJSON(data []byte) error {
+	type Alias Version
+	extra, err := unmarshalExtra(data, (*Alias)(version))
+	if err != nil {
+		return err
+	}
+	version.Extra = extra
+	return nil
 }
 
 func (c *Client) Version(id int) (*Version, error) {
@@ -40,7 +67,7 @@ func (c *Client) Version(id int) (*Version, error) {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
@@ -68,7 +95,7 @@ func (c *Client) Versions(projectId int) ([]Version, error) {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
@@ -101,7 +128,7 @@ func (c *Client) CreateVersion(version Version, userName ...string) (*Version, e
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -110,7 +137,7 @@ func (c *Client) CreateVersion(version Version, userName ...string) (*Version, e
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)
@@ -143,7 +170,7 @@ func (c *Client) UpdateVersion(version Version, userName ...string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -152,7 +179,7 @@ func (c *Client) UpdateVersion(version Version, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -171,7 +198,7 @@ func (c *Client) DeleteVersion(id int, userName ...string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -180,7 +207,7 @@ func (c *Client) DeleteVersion(id int, userName ...string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
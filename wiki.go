@@ -3,7 +3,9 @@ package redmine
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -20,16 +22,74 @@ type wikiPageRequest struct {
 	WikiPage WikiPage `json:"wiki_page"`
 }
 
+// MarshalJSON leaves the read-only timestamps out of create and update requests.
+func (r wikiPageRequest) MarshalJSON() ([]byte, error) {
+	wikiPage, err := marshalWithout(r.WikiPage, "created_on", "updated_on")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		WikiPage json.RawMessage `json:"wiki_page"`
+	}{wikiPage})
+}
+
+type wikiPageRenameRequest struct {
+	WikiPage struct {
+		Title                 string `json:"title"`
+		RedirectExistingLinks string `json:"redirect_existing_links"` // "1" or "0"
+		Text                  string `json:"text"`
+		Version               int    `json:"version"`
+	} `json:"wiki_page"`
+}
+
 type WikiPage struct {
-	Title     string      `json:"title"`
-	Parent    *Parent     `json:"parent,omitempty"`
-	Text      string      `json:"text"`
-	Version   interface{} `json:"version,omitempty"`
-	Author    *IdName     `json:"author,omitempty"`
-	Comments  string      `json:"comments"`
-	CreatedOn string      `json:"created_on,omitempty"`
-	UpdatedOn string      `json:"updated_on,omitempty"`
-	ParentID  int         `json:"parent_id"`
+	Title       string                 `json:"title"`
+	Parent      *Parent                `json:"parent,omitempty"`
+	Text        string                 `json:"text"`
+	Version     int                    `json:"version,omitempty"` // on update, the version the change is based on
+	Author      *IdName                `json:"author,omitempty"`
+	Comments    string                 `json:"comments"`
+	CreatedOn   Timestamp              `json:"created_on"`
+	UpdatedOn   Timestamp              `json:"updated_on"`
+	ParentID    int                    `json:"parent_id"`
+	ParentTitle string                 `json:"parent_title,omitempty"`
+	Attachments []*Attachment          `json:"attachments,omitempty"`
+	Uploads     []*Upload              `json:"uploads,omitempty"` // files to attach on create or update
+	Extra       map[string]interface{} `json:"-"`
+}
+
+func (wikiPage WikiPage) MarshalJSON() ([]byte, error) {
+	type Alias WikiPage
+	return marshalExtra(Alias(wikiPage), wikiPage.Extra)
+}
+
+func (wikiPage *WikiPage) UnmarshalJSON(data []byte) error {
+	type Alias WikiPage
+	extra, err := unmarshalExtra(data, (*Alias)(wikiPage))
+	if err != nil {
+		return err
+	}
+	wikiPage.Extra = extra
+	return nil
+}
+
+// WikiPageVersion describes one entry of the history of a wiki page.
+type WikiPageVersion struct {
+	Version   int       `json:"version"`
+	Author    *IdName   `json:"author,omitempty"`
+	Comments  string    `json:"comments"`
+	UpdatedOn Timestamp `json:"updated_on"`
+}
+
+// WikiPageConflictError is returned when a wiki page was modified by someone
+// else since the version the update was based on.
+type WikiPageConflictError struct {
+	Title   string
+	Version int
+}
+
+func (e *WikiPageConflictError) Error() string {
+	return fmt.Sprintf("wiki page %q was modified since version %d", e.Title, e.Version)
 }
 
 type Parent struct {
@@ -48,7 +108,7 @@ func (c *Client) WikiPages(projectId int) ([]WikiPage, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r wikiPagesResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -64,18 +124,57 @@ func (c *Client) WikiPages(projectId int) ([]WikiPage, error) {
 	return r.WikiPages, nil
 }
 
-// WikiPage fetches the wiki page with the given title.
+// WikiPage fetches the wiki page with the given title, including its attachments.
 func (c *Client) WikiPage(projectId int, title string) (*WikiPage, error) {
-	return c.getWikiPage(projectId, title)
+	return c.getWikiPage(projectId, url.PathEscape(title))
 }
 
-// WikiPageAtVersion fetches the wiki page with the given title at the given version.
+// WikiPageAtVersion fetches the wiki page with the given title at the given version, including its attachments.
 func (c *Client) WikiPageAtVersion(projectId int, title string, version string) (*WikiPage, error) {
-	return c.getWikiPage(projectId, title+"/"+version)
+	return c.getWikiPage(projectId, url.PathEscape(title)+"/"+url.PathEscape(version))
 }
 
-func (c *Client) getWikiPage(projectId int, resource string) (*WikiPage, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/wiki/" + resource + ".json?key=" + c.apikey)
+// WikiPageVersions lists the versions of the wiki page newer than since, oldest
+// first; a since of zero lists the whole history. The REST API has no history
+// endpoint, so every version is fetched individually: listing n versions costs
+// n requests. Versions deleted from the history are skipped.
+func (c *Client) WikiPageVersions(projectId int, title string, since int) ([]WikiPageVersion, error) {
+	current, err := c.getWikiPage(projectId, url.PathEscape(title))
+	if err != nil {
+		return nil, err
+	}
+	if since >= current.Version {
+		return []WikiPageVersion{}, nil
+	}
+	if since < 0 {
+		since = 0
+	}
+	versions := make([]WikiPageVersion, 0, current.Version-since)
+	for v := since + 1; v < current.Version; v++ {
+		page, err := c.getWikiPage(projectId, url.PathEscape(title)+"/"+strconv.Itoa(v))
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		versions = append(versions, wikiPageVersionOf(page))
+	}
+	return append(versions, wikiPageVersionOf(current)), nil
+}
+
+func wikiPageVersionOf(page *WikiPage) WikiPageVersion {
+	return WikiPageVersion{
+		Version:   page.Version,
+		Author:    page.Author,
+		Comments:  page.Comments,
+		UpdatedOn: page.UpdatedOn,
+	}
+}
+
+// getWikiPage fetches the page at path, the escaped title optionally followed by "/" and a version.
+func (c *Client) getWikiPage(projectId int, path string) (*WikiPage, error) {
+	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/wiki/" + path + ".json?include=attachments&key=" + c.apikey)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +183,7 @@ func (c *Client) getWikiPage(projectId int, resource string) (*WikiPage, error)
 	decoder := json.NewDecoder(res.Body)
 	var r wikiPageResult
 	if res.StatusCode == 404 {
-		return nil, errors.New("not found")
+		return nil, ErrNotFound
 	}
 	if res.StatusCode != 200 {
 		var er errorsResult
@@ -100,7 +199,7 @@ func (c *Client) getWikiPage(projectId int, resource string) (*WikiPage, error)
 	return &r.WikiPage, nil
 }
 
-// CreateWikiPage creates wiki page.
+// CreateWikiPage creates wiki page. Files previously sent with Upload are attached through the Uploads field.
 func (c *Client) CreateWikiPage(projectId int, wikiPage WikiPage, userName ...string) (*WikiPage, error) {
 	var wpr wikiPageRequest
 	wpr.WikiPage = wikiPage
@@ -108,13 +207,13 @@ func (c *Client) CreateWikiPage(projectId int, wikiPage WikiPage, userName ...st
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+wikiPage.Title+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+url.PathEscape(wikiPage.Title)+".json?key="+c.apikey, strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -122,6 +221,10 @@ func (c *Client) CreateWikiPage(projectId int, wikiPage WikiPage, userName ...st
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusConflict {
+		return nil, &WikiPageConflictError{Title: wikiPage.Title, Version: wikiPage.Version}
+	}
+
 	decoder := json.NewDecoder(res.Body)
 	var r wikiPageResult
 	if res.StatusCode != 201 {
@@ -139,6 +242,8 @@ func (c *Client) CreateWikiPage(projectId int, wikiPage WikiPage, userName ...st
 }
 
 // UpdateWikiPage updates the wiki page given by the Title field of wikiPage.
+// When Version is set, a *WikiPageConflictError is returned if the page has
+// been modified since that version.
 func (c *Client) UpdateWikiPage(projectId int, wikiPage WikiPage, userName ...string) error {
 	var wpr wikiPageRequest
 	wpr.WikiPage = wikiPage
@@ -146,13 +251,13 @@ func (c *Client) UpdateWikiPage(projectId int, wikiPage WikiPage, userName ...st
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+wikiPage.Title+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+url.PathEscape(wikiPage.Title)+".json?key="+c.apikey, strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if len(userName) > 0 {
-		req.Header.Set("X-Redmine-Switch-User", userName[0])	
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
 	}
 	res, err := c.Do(req)
 	if err != nil {
@@ -160,10 +265,65 @@ func (c *Client) UpdateWikiPage(projectId int, wikiPage WikiPage, userName ...st
 	}
 	defer res.Body.Close()
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
+	}
+	if res.StatusCode == http.StatusConflict {
+		return &WikiPageConflictError{Title: wikiPage.Title, Version: wikiPage.Version}
 	}
 
-	if res.StatusCode != 200 {
+	if res.StatusCode/100 != 2 {
+		decoder := json.NewDecoder(res.Body)
+		var er errorsResult
+		if err := decoder.Decode(&er); err != nil {
+			return err
+		}
+		return errors.New(strings.Join(er.Errors, "\n"))
+	}
+	return nil
+}
+
+// RenameWikiPage renames the wiki page oldTitle to newTitle. When redirect is true,
+// links to the old title keep working through a redirect. The text of the page is
+// sent unchanged along with the rename, which Redmine requires, so no new version
+// is created.
+func (c *Client) RenameWikiPage(projectId int, oldTitle, newTitle string, redirect bool, userName ...string) error {
+	page, err := c.getWikiPage(projectId, url.PathEscape(oldTitle))
+	if err != nil {
+		return err
+	}
+	var rename wikiPageRenameRequest
+	rename.WikiPage.Title = newTitle
+	rename.WikiPage.RedirectExistingLinks = "0"
+	if redirect {
+		rename.WikiPage.RedirectExistingLinks = "1"
+	}
+	rename.WikiPage.Text = page.Text
+	rename.WikiPage.Version = page.Version
+	s, err := json.Marshal(rename)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+url.PathEscape(oldTitle)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(userName) > 0 {
+		req.Header.Set("X-Redmine-Switch-User", userName[0])
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == 404 {
+		return ErrNotFound
+	}
+	if res.StatusCode == http.StatusConflict {
+		return &WikiPageConflictError{Title: oldTitle, Version: page.Version}
+	}
+
+	if res.StatusCode/100 != 2 {
 		decoder := json.NewDecoder(res.Body)
 		var er errorsResult
 		if err := decoder.Decode(&er); err != nil {
@@ -176,7 +336,7 @@ func (c *Client) UpdateWikiPage(projectId int, wikiPage WikiPage, userName ...st
 
 // DeleteWikiPage deletes the wiki page given by its title irreversibly.
 func (c *Client) DeleteWikiPage(projectId int, title string) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+title+".json?key="+c.apikey, strings.NewReader(""))
+	req, err := http.NewRequest("DELETE", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+url.PathEscape(title)+".json?key="+c.apikey, strings.NewReader(""))
 	if err != nil {
 		return err
 	}
@@ -188,7 +348,7 @@ func (c *Client) DeleteWikiPage(projectId int, title string) error {
 	defer res.Body.Close()
 
 	if res.StatusCode == 404 {
-		return errors.New("not found")
+		return ErrNotFound
 	}
 
 	decoder := json.NewDecoder(res.Body)